@@ -0,0 +1,66 @@
+package tsp
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"slices"
+	"time"
+)
+
+// Names of the solvers.
+const (
+	GeneticSolver   = "ga"
+	AnnealingSolver = "sa"
+	TabuSolver      = "tabu"
+	ExactSolver     = "exact"
+	AutoSolver      = "auto"
+)
+
+// SAOptions are the parameters of a simulated annealing run.
+type SAOptions struct {
+	Temperature float64 `json:"temperature"`
+	Cooling     float64 `json:"cooling"`
+	Iterations  int     `json:"iterations"`
+}
+
+// SimulatedAnnealing searches for a short tour by simulated annealing. Each
+// iteration proposes a 2-opt move (reversing a random segment), which is kept if
+// it shortens the tour, or otherwise with a probability that falls as the tour
+// gets longer and the temperature cools geometrically. The best tour found is
+// returned.
+func SimulatedAnnealing(rng *rand.Rand, gt Genotype, opts SAOptions) Tour {
+	return SimulatedAnnealingContext(context.Background(), rng, gt, opts).Best
+}
+
+// SimulatedAnnealingContext is like SimulatedAnnealing, but also stops when the
+// context is done, returning the best tour found so far with metadata about
+// the run.
+func SimulatedAnnealingContext(ctx context.Context, rng *rand.Rand, gt Genotype, opts SAOptions) Result {
+	start := time.Now()
+	done := ctx.Done()
+	reason := StopMaxGenerations
+	tour := gt.RandomTour(rng)
+	score := tour.Score()
+	best, bestScore := slices.Clone(tour.path), score
+	temperature := opts.Temperature
+	iteration := 0
+	for ; iteration < opts.Iterations; iteration++ {
+		if stopped(done) {
+			reason = canceled(ctx)
+			break
+		}
+		mn, mx := randRange(rng, len(tour.path))
+		delta := tour.inversionDelta(mn, mx)
+		if delta < 0 || rng.Float64() < math.Exp(-delta/temperature) {
+			slices.Reverse(tour.path[mn : mx+1])
+			if score += delta; score < bestScore {
+				copy(best, tour.path)
+				bestScore = score
+			}
+		}
+		temperature *= opts.Cooling
+	}
+	tour.path, tour.dirty = best, true
+	return Result{tour, tour.Score(), iteration, time.Since(start), reason}
+}
@@ -0,0 +1,66 @@
+package tsp
+
+import (
+	"math"
+	"strings"
+)
+
+// Letters that mark the cities of an ASCII map, in the order visited.
+const asciiMarkers = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// RenderASCII draws a tour on a text grid of the given size, with a letter
+// marking each city (in the order visited, repeating after z) and dots for the
+// edges between them. Rows are separated by newlines.
+func (t Tour) RenderASCII(rows, cols int) string {
+	if rows < 1 || cols < 1 {
+		return ""
+	}
+	grid := make([][]byte, rows)
+	for r := range grid {
+		grid[r] = []byte(strings.Repeat(" ", cols))
+	}
+	cities := t.Cities()
+	minLon, minLat, maxLon, maxLat := bounds(cities)
+	// Map a city to its row and column, scaled to the bounding box of the tour
+	cell := func(c City) (int, int) {
+		scale := func(v, lo, hi float64, n int) int {
+			if hi <= lo {
+				return 0
+			}
+			return int(math.Round((v - lo) / (hi - lo) * float64(n-1)))
+		}
+		return (rows - 1) - scale(c.Lat, minLat, maxLat, rows), scale(c.Lon, minLon, maxLon, cols)
+	}
+	edges := len(cities)
+	if t.open || edges < 2 {
+		edges--
+	}
+	for i := 0; i < edges; i++ {
+		r1, c1 := cell(cities[i])
+		r2, c2 := cell(cities[(i+1)%len(cities)])
+		steps := max(abs(r2-r1), abs(c2-c1))
+		for s := 1; s < steps; s++ {
+			r := r1 + int(math.Round(float64((r2-r1)*s)/float64(steps)))
+			c := c1 + int(math.Round(float64((c2-c1)*s)/float64(steps)))
+			grid[r][c] = '.'
+		}
+	}
+	for i, city := range cities {
+		r, c := cell(city)
+		grid[r][c] = asciiMarkers[i%len(asciiMarkers)]
+	}
+	var b strings.Builder
+	for _, row := range grid {
+		b.Write(row)
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Return the absolute value of an integer.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,86 @@
+package tsp
+
+import (
+	_ "embed"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// The state capitals, a realistic instance to benchmark with.
+//
+//go:embed capitals.tsp
+var capitals string
+
+// Load the embedded capitals.
+func loadCapitals(b *testing.B) Genotype {
+	b.Helper()
+	gt := Genotype{}
+	if err := gt.InitReader(strings.NewReader(capitals)); err != nil {
+		b.Fatal(err)
+	}
+	return gt
+}
+
+// Benchmark scoring a tour by looking up the distance matrix, and by computing
+// great circle distances with trigonometry instead.
+func BenchmarkScore(b *testing.B) {
+	gt := loadCapitals(b)
+	tour := gt.RandomTour(rand.New(rand.NewSource(1)))
+	trig := tour.Clone()
+	trig.matrix = nil
+	for _, bench := range []struct {
+		name string
+		tour Tour
+	}{{"matrix", tour}, {"trig", trig}} {
+		b.Run(bench.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.ResetTimer()
+			for range b.N {
+				bench.tour.dirty = true
+				bench.tour.Score()
+			}
+		})
+	}
+}
+
+func BenchmarkCrossover(b *testing.B) {
+	gt := loadCapitals(b)
+	rng := rand.New(rand.NewSource(1))
+	cfg := DefaultConfig()
+	for _, op := range []string{PrefixCrossover, OrderCrossover, PMXCrossover, CycleCrossover, EdgeCrossover} {
+		b.Run(op, func(b *testing.B) {
+			cfg.Crossover = op
+			t1, t2 := gt.RandomTour(rng), gt.RandomTour(rng)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for range b.N {
+				t1.Crossover(rng, t2, cfg)
+			}
+		})
+	}
+}
+
+func BenchmarkMutate(b *testing.B) {
+	gt := loadCapitals(b)
+	rng := rand.New(rand.NewSource(1))
+	tour := gt.RandomTour(rng)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		tour.Mutate(rng, 1)
+	}
+}
+
+func BenchmarkEvolve(b *testing.B) {
+	gt := loadCapitals(b)
+	rng := rand.New(rand.NewSource(1))
+	cfg := DefaultConfig()
+	p := Population{}
+	p.Init(rng, gt, cfg.Population, cfg.HeuristicFraction)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		p.Evolve(rng, cfg)
+	}
+}
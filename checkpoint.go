@@ -0,0 +1,110 @@
+package tsp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// Snapshot is the population of a GA island after a number of generations,
+// from which the island can be resumed.
+type Snapshot struct {
+	Generation int
+	Population Population
+}
+
+// The JSON form of a population: the names of the cities of each tour, in the
+// order visited.
+type populationJSON struct {
+	Generation int        `json:"generation"`
+	Tours      [][]string `json:"tours"`
+}
+
+// Save writes a population and the number of generations it has evolved to a
+// writer, as one JSON object. Saved populations can be written one after another
+// to the same writer (for example, one per island).
+func (p Population) Save(w io.Writer, generation int) error {
+	v := populationJSON{Generation: generation, Tours: make([][]string, len(p.solutions))}
+	for i, tour := range p.solutions {
+		for _, city := range tour.Cities() {
+			v.Tours[i] = append(v.Tours[i], city.Name)
+		}
+	}
+	return json.NewEncoder(w).Encode(v)
+}
+
+// LoadPopulation reads a population saved by Save through the cities of a
+// genotype, and returns it with the number of generations it had evolved.
+func LoadPopulation(r io.Reader, gt Genotype) (Population, int, error) {
+	return loadPopulation(json.NewDecoder(r), gt)
+}
+
+// Decode the next saved population.
+func loadPopulation(d *json.Decoder, gt Genotype) (p Population, generation int, err error) {
+	var v populationJSON
+	if err = d.Decode(&v); err != nil {
+		return
+	}
+	index := make(map[string]int, len(gt.genes))
+	for i, city := range gt.genes {
+		index[city.Name] = i
+	}
+	for _, names := range v.Tours {
+		tour := gt.emptyTour()
+		for _, name := range names {
+			i, ok := index[name]
+			if !ok {
+				return Population{}, 0, fmt.Errorf("Unknown city: %s", name)
+			}
+			tour.path = append(tour.path, i)
+		}
+		if err = tour.Validate(gt); err != nil {
+			return Population{}, 0, err
+		}
+		p.solutions = append(p.solutions, tour)
+	}
+	return p, v.Generation, nil
+}
+
+// SaveCheckpoint writes the snapshots of the islands of a run to a writer.
+func SaveCheckpoint(w io.Writer, snapshots []Snapshot) error {
+	for _, s := range snapshots {
+		if err := s.Population.Save(w, s.Generation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadCheckpoint reads the snapshots written by SaveCheckpoint through the
+// cities of a genotype.
+func LoadCheckpoint(r io.Reader, gt Genotype) (snapshots []Snapshot, err error) {
+	d := json.NewDecoder(r)
+	for d.More() {
+		var s Snapshot
+		if s.Population, s.Generation, err = loadPopulation(d, gt); err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, s)
+	}
+	return snapshots, nil
+}
+
+// Replace the tours of a population with copies of the tours of a snapshot,
+// scored by the given genotype (any tours left over stay as they are).
+func (p *Population) resume(gt Genotype, s Snapshot) {
+	for i, tour := range s.Population.solutions[:min(len(p.solutions), len(s.Population.solutions))] {
+		p.solutions[i] = gt.emptyTour()
+		p.solutions[i].path = slices.Clone(tour.path)
+	}
+}
+
+// Return a copy of a population with copies of its tours.
+func (p Population) clone() Population {
+	solutions := make([]Tour, len(p.solutions))
+	for i, tour := range p.solutions {
+		solutions[i] = tour.Clone()
+	}
+	return Population{solutions: solutions}
+}
@@ -0,0 +1,80 @@
+package tsp
+
+import (
+	"bytes"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCheckpointRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	gt := randomGenotype(rng, 10, false, false)
+	snapshots := []Snapshot{{3, randomPopulation(rng, gt, 5)}, {7, randomPopulation(rng, gt, 4)}}
+	var buf bytes.Buffer
+	if err := SaveCheckpoint(&buf, snapshots); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadCheckpoint(&buf, gt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded) != len(snapshots) {
+		t.Fatalf("loaded %d snapshots, want %d", len(loaded), len(snapshots))
+	}
+	for i, s := range loaded {
+		if s.Generation != snapshots[i].Generation || len(s.Population.solutions) != len(snapshots[i].Population.solutions) {
+			t.Fatalf("snapshot %d: generation %d of %d tours", i, s.Generation, len(s.Population.solutions))
+		}
+		for j, tour := range s.Population.solutions {
+			if !slices.Equal(tour.path, snapshots[i].Population.solutions[j].path) {
+				t.Errorf("snapshot %d tour %d: %v, want %v", i, j, tour.path, snapshots[i].Population.solutions[j].path)
+			}
+			checkScore(t, tour, "loaded tour")
+		}
+	}
+	for _, input := range []string{`{"generation": 1, "tours": [["0", "1", "x"]]}`, `{"generation": 1, "tours": [["0", "1"]]}`, `{"generation"`} {
+		if _, err := LoadCheckpoint(strings.NewReader(input), gt); err == nil {
+			t.Errorf("%s: no error", input)
+		}
+	}
+}
+
+func TestResume(t *testing.T) {
+	gt := randomGenotype(rand.New(rand.NewSource(1)), 20, false, false)
+	cfg := DefaultConfig()
+	cfg.Seed, cfg.MaxGenerations, cfg.CheckpointInterval = 1, 10, 5
+	var snapshots []Snapshot
+	cfg.OnCheckpoint = func(s []Snapshot) { snapshots = s }
+	first, err := SolveDeterministic(gt, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 1 || snapshots[0].Generation != 10 {
+		t.Fatalf("checkpoints %+v, want one at generation 10", snapshots)
+	}
+
+	// A resumed island counts on from the generation of its snapshot
+	var buf bytes.Buffer
+	if err := SaveCheckpoint(&buf, snapshots); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Resume, err = LoadCheckpoint(&buf, gt); err != nil {
+		t.Fatal(err)
+	}
+	cfg.MaxGenerations, cfg.OnCheckpoint = 20, nil
+	start := -1
+	cfg.OnGeneration = func(stats Stats) {
+		if start < 0 {
+			start = stats.Generation
+		}
+	}
+	resumed, err := SolveDeterministic(gt, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if start != 10 || resumed.Generations != 20 || resumed.Score > first.Score {
+		t.Errorf("resumed at generation %d for %d generations, scoring %f after %f", start, resumed.Generations, resumed.Score, first.Score)
+	}
+}
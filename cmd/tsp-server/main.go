@@ -0,0 +1,135 @@
+// Command tsp-server finds "good enough" tours through cities posted over HTTP.
+//
+// POST /solve takes a JSON object with the cities to tour and optional GA
+// parameters in the format of a config file:
+//
+//	{"cities": [{"name": "A", "lat": 1, "lon": 2}, ...], "config": {"duration": "5s"}}
+//
+// and responds with the best tour found and metadata about the run.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"runtime"
+	"time"
+
+	"github.com/carp-sushi/tsp"
+)
+
+// Largest request body accepted, in bytes.
+const maxBodySize = 10 << 20
+
+// A request to solve a set of cities.
+type solveRequest struct {
+	Cities []tsp.City      `json:"cities"`
+	Config json.RawMessage `json:"config"`
+}
+
+// Bounds on the work a request may ask for.
+type limits struct {
+	duration   time.Duration // Longest time limit, which shorter limits replace
+	cities     int           // Most cities (whose distance matrix grows with their square)
+	workers    int           // Most GA islands
+	population int           // Largest population of an island
+	offspring  int           // Most children bred per generation
+	iterations int           // Most iterations of simulated annealing or tabu search
+}
+
+// Check that the cities and config of a request stay within the limits, capping
+// its time limit.
+func (l limits) apply(cities []tsp.City, cfg *tsp.Config) error {
+	if len(cities) > l.cities {
+		return fmt.Errorf("Cities must be at most %d", l.cities)
+	}
+	cfg.Duration = min(cfg.Duration, l.duration)
+	if cfg.Workers > l.workers {
+		return fmt.Errorf("Workers must be at most %d", l.workers)
+	}
+	if cfg.Population > l.population {
+		return fmt.Errorf("Population must be at most %d", l.population)
+	}
+	if cfg.Offspring > l.offspring {
+		return fmt.Errorf("Offspring must be at most %d", l.offspring)
+	}
+	if cfg.Solver == tsp.AnnealingSolver && cfg.Annealing.Iterations > l.iterations ||
+		cfg.Solver == tsp.TabuSolver && cfg.Tabu.Iterations > l.iterations {
+		return fmt.Errorf("Iterations must be at most %d", l.iterations)
+	}
+	return nil
+}
+
+// The response to a solved request.
+type solveResponse struct {
+	Tour        tsp.Tour `json:"tour"`
+	Score       float64  `json:"score"`
+	Generations int      `json:"generations"`
+	Elapsed     string   `json:"elapsed"`
+	StopReason  string   `json:"stop_reason"`
+}
+
+// Serve solve requests.
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	l := limits{}
+	flag.DurationVar(&l.duration, "max-duration", time.Minute, "longest time limit a request may set")
+	flag.IntVar(&l.cities, "max-cities", 5000, "most cities a request may post")
+	flag.IntVar(&l.workers, "max-workers", max(2, runtime.NumCPU()), "most GA islands a request may run")
+	flag.IntVar(&l.population, "max-population", 1000, "largest population a request may set")
+	flag.IntVar(&l.offspring, "max-offspring", 1000, "most offspring per generation a request may set")
+	flag.IntVar(&l.iterations, "max-iterations", 10000000, "most simulated annealing or tabu search iterations a request may set")
+	flag.Parse()
+
+	mux := http.NewServeMux()
+	mux.Handle("POST /solve", solveHandler(l))
+	log.Printf("Listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, mux))
+}
+
+// Return a handler that solves the posted cities, within the given limits. The
+// solver stops early if the client goes away.
+func solveHandler(l limits) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req solveRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid request: %s", err), http.StatusBadRequest)
+			return
+		}
+		cfg := tsp.DefaultConfig()
+		if len(req.Config) > 0 {
+			if err := json.Unmarshal(req.Config, &cfg); err != nil {
+				http.Error(w, fmt.Sprintf("Invalid config: %s", err), http.StatusBadRequest)
+				return
+			}
+		}
+		if err := l.apply(req.Cities, &cfg); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		gt := tsp.Genotype{}
+		gt.SetPlanar(cfg.Planar)
+		if err := gt.InitCities(req.Cities); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid cities: %s", err), http.StatusBadRequest)
+			return
+		}
+		result, err := tsp.SolveContext(r.Context(), gt, cfg)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if r.Context().Err() != nil {
+			return // The client is gone
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(solveResponse{
+			Tour:        result.Best,
+			Score:       result.Score,
+			Generations: result.Generations,
+			Elapsed:     result.Elapsed.Round(time.Millisecond).String(),
+			StopReason:  result.StopReason,
+		})
+	})
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Limits that any test request stays within.
+var testLimits = limits{duration: time.Second, cities: 200, workers: 2, population: 100, offspring: 10, iterations: 1000000}
+
+// Five cities to post.
+const testCities = `[
+	{"name": "A", "lat": 1, "lon": 2},
+	{"name": "B", "lat": 3, "lon": 4},
+	{"name": "C", "lat": 5, "lon": 1},
+	{"name": "D", "lat": 2, "lon": 6},
+	{"name": "E", "lat": 7, "lon": 7}
+]`
+
+// Post a request body to the solve endpoint of a server.
+func post(t *testing.T, server *httptest.Server, body string) *http.Response {
+	t.Helper()
+	resp, err := http.Post(server.URL+"/solve", "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestSolve(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("POST /solve", solveHandler(testLimits))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	for _, solver := range []string{"ga", "auto", "exact", "sa", "tabu"} {
+		body := `{"cities": ` + testCities + `, "config": {"solver": "` + solver + `", "duration": "200ms", "workers": 2}}`
+		resp := post(t, server, body)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("%s: status %s", solver, resp.Status)
+		}
+		var v struct {
+			Tour struct {
+				Path []struct {
+					Name string `json:"name"`
+				} `json:"path"`
+			} `json:"tour"`
+			Score      float64 `json:"score"`
+			StopReason string  `json:"stop_reason"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&v); err != nil {
+			t.Fatalf("%s: %s", solver, err)
+		}
+		var names []string
+		for _, city := range v.Tour.Path {
+			names = append(names, city.Name)
+		}
+		slices.Sort(names)
+		if !slices.Equal(names, []string{"A", "B", "C", "D", "E"}) {
+			t.Errorf("%s: tour visits %v, want each city once", solver, names)
+		}
+		if v.Score <= 0 {
+			t.Errorf("%s: score %f, want positive", solver, v.Score)
+		}
+		if (solver == "exact" || solver == "auto") && v.StopReason != "exact" {
+			t.Errorf("%s: stopped by %q, want exact", solver, v.StopReason)
+		}
+	}
+}
+
+func TestSolveRejects(t *testing.T) {
+	server := httptest.NewServer(solveHandler(testLimits))
+	defer server.Close()
+
+	for _, body := range []string{
+		`{"cities": []}`,
+		`{"cities": [{"name": "A", "lat": 1, "lon": 2}, {"name": "A", "lat": 3, "lon": 4}]}`,
+		`{"cities": ` + testCities + `, "config": {"population": 1000000}}`,
+		`{"cities": ` + testCities + `, "config": {"workers": 100}}`,
+		`{"cities": ` + testCities + `, "config": {"solver": "sa", "annealing": {"iterations": 1000000000}}}`,
+		`{"cities": ` + testCities + `, "config": {"solver": "nope"}}`,
+		`{"cities": [` + strings.Repeat(`{"name": "A", "lat": 1, "lon": 2}, `, 200) + `{"name": "B", "lat": 1, "lon": 2}]}`,
+		`not json`,
+	} {
+		if resp := post(t, server, body); resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("%s: status %s, want %d", body, resp.Status, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestSolveStopsAtDuration(t *testing.T) {
+	server := httptest.NewServer(solveHandler(testLimits))
+	defer server.Close()
+
+	var cities []string
+	for i := range 200 {
+		cities = append(cities, fmt.Sprintf(`{"name": "%d", "lat": %d, "lon": %d}`, i, i%90, i*7%180))
+	}
+	for _, solver := range []string{"sa", "tabu"} {
+		body := `{"cities": [` + strings.Join(cities, ", ") + `], "config": {"solver": "` + solver + `", "duration": "100ms"}}`
+		start := time.Now()
+		resp := post(t, server, body)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("%s: status %s", solver, resp.Status)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("%s: took %s with a 100ms time limit", solver, elapsed)
+		}
+	}
+}
@@ -0,0 +1,381 @@
+// Command tsp finds a "good enough" tour through a file of cities.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/carp-sushi/tsp"
+)
+
+// The file of cities to tour when none is configured.
+const defaultInput = "capitals.tsp"
+
+// Generations between checkpoints when a checkpoint file is given without an
+// interval.
+const defaultCheckpointInterval = 100
+
+// Find a "good enough" solution to the TSP for a file of cities.
+func main() {
+	unit := tsp.Miles
+	flag.Var(&unit, "units", "units for reported distances: mi, km, or nmi")
+	deterministic := flag.Bool("deterministic", false, "run a single island without a time limit, so a seed always gives the same tour")
+	verbose := flag.Bool("verbose", false, "log solver events and print population statistics every generation")
+	asciiMap := flag.Bool("ascii-map", false, "draw the best tour on a text grid when done")
+	checkpoint := flag.String("checkpoint", "", "file to periodically save the GA populations to")
+	resume := flag.String("resume", "", "checkpoint file of GA populations to resume from")
+	cfg, err := parseConfig()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flag.Usage()
+		os.Exit(2)
+	}
+	if cfg.Seed == 0 && !*deterministic {
+		cfg.Seed = time.Now().UnixNano()
+	}
+	gt := tsp.Genotype{}
+	gt.SetPlanar(cfg.Planar)
+	gt.SetStrict(cfg.Strict)
+	if err := initGenotype(&gt, cfg.Input); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	unitSet := false
+	flag.Visit(func(f *flag.Flag) { unitSet = unitSet || f.Name == "units" })
+	if unitSet && !gt.Geographic() {
+		fmt.Fprintln(os.Stderr, "Units need great circle distances, not planar, TSPLIB or matrix distances")
+		os.Exit(2)
+	}
+
+	show := func(tour tsp.Tour) {
+		if gt.Geographic() {
+			fmt.Printf("Score = %f %s\n", tour.ScoreIn(unit), unit)
+		} else {
+			fmt.Printf("Score = %f\n", tour.Score())
+		}
+		tour.Print()
+	}
+	cfg.OnImprovement = func(tour tsp.Tour, stats tsp.Stats) {
+		fmt.Printf("Generation = %d, Elapsed = %s\n", stats.Generation, stats.Elapsed.Round(time.Millisecond))
+		show(tour)
+	}
+	if *verbose {
+		cfg.Logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		cfg.OnGeneration = func(stats tsp.Stats) {
+			fmt.Printf("Generation = %d, Best = %f, Mean = %f, Worst = %f, Diversity = %f\n",
+				stats.Generation, stats.Score, stats.Mean, stats.Worst, stats.Diversity)
+		}
+	}
+	cfg.OnTop = func(tours []tsp.Tour) {
+		for i, tour := range tours {
+			fmt.Printf("Top %d: ", i+1)
+			show(tour)
+		}
+	}
+	if *checkpoint != "" {
+		if cfg.CheckpointInterval == 0 {
+			cfg.CheckpointInterval = defaultCheckpointInterval
+		}
+		cfg.OnCheckpoint = func(snapshots []tsp.Snapshot) {
+			if err := writeCheckpoint(*checkpoint, snapshots); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+		}
+	}
+	if *resume != "" {
+		if cfg.Resume, err = readCheckpoint(*resume, gt); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	solve := tsp.SolveContext
+	if *deterministic {
+		solve = tsp.SolveDeterministicContext
+	}
+	ctx, stop := interruptible()
+	defer stop()
+	result, err := solve(ctx, gt, cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	best := result.Best
+	if result.StopReason == tsp.StopCanceled {
+		fmt.Println("Interrupted, best tour found:")
+		show(best)
+	}
+	workers := cfg.Workers
+	switch {
+	case *deterministic, cfg.Solver == tsp.AnnealingSolver, cfg.Solver == tsp.TabuSolver,
+		result.StopReason == tsp.StopExact, result.StopReason == tsp.StopTrivial:
+		workers = 1
+	}
+	fmt.Printf("Workers = %d, Generations = %d, Elapsed = %s, Stopped = %s\n",
+		workers, result.Generations, result.Elapsed.Round(time.Millisecond), result.StopReason)
+	if cfg.Optimum > 0 {
+		fmt.Printf("Gap = %.2f%%\n", tsp.Gap(best.Score(), cfg.Optimum))
+	}
+	if cfg.Output != "" {
+		if err := writeTour(cfg.Output, best); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	if *asciiMap {
+		fmt.Print(best.RenderASCII(24, 80))
+	}
+	fmt.Println("Done.")
+}
+
+// Return a context that is canceled by the first SIGINT or SIGTERM, so the
+// solver stops and its best tour can be reported. A second signal exits
+// immediately. The returned function stops listening for signals.
+func interruptible() (context.Context, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		if _, ok := <-signals; !ok {
+			return
+		}
+		fmt.Fprintln(os.Stderr, "Stopping, interrupt again to exit now")
+		cancel()
+		if _, ok := <-signals; ok {
+			os.Exit(130)
+		}
+	}()
+	return ctx, func() {
+		signal.Stop(signals)
+		close(signals)
+		cancel()
+	}
+}
+
+// Load the cities of a genotype from a file, or from stdin if the file is "-".
+func initGenotype(gt *tsp.Genotype, file string) error {
+	if file == "-" {
+		return gt.InitReader(os.Stdin)
+	}
+	return gt.Init(file)
+}
+
+// Write a tour to a file, replacing any previous contents.
+func writeTour(path string, tour tsp.Tour) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := tour.WriteTo(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Write the snapshots of a run to a checkpoint file. The snapshots are written
+// to a temporary file that then replaces the checkpoint, so an interrupted
+// write never leaves a partial checkpoint.
+func writeCheckpoint(path string, snapshots []tsp.Snapshot) error {
+	file, err := os.Create(path + ".tmp")
+	if err != nil {
+		return err
+	}
+	if err := tsp.SaveCheckpoint(file, snapshots); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// Read the snapshots of a run from a checkpoint file.
+func readCheckpoint(path string, gt tsp.Genotype) ([]tsp.Snapshot, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return tsp.LoadCheckpoint(file, gt)
+}
+
+// Parse command-line flags into a config. Flags that are set take precedence
+// over the values of an optional config file, which take precedence over the
+// defaults.
+func parseConfig() (tsp.Config, error) {
+	flags := tsp.DefaultConfig()
+	flags.Input = defaultInput
+	file := flag.String("config", "", "JSON file of GA parameters")
+	flag.StringVar(&flags.Input, "input", flags.Input, "file of cities to tour (- reads stdin)")
+	flag.StringVar(&flags.Output, "output", flags.Output, "file to write the best tour to (default stdout only)")
+	flag.BoolVar(&flags.Planar, "planar", flags.Planar, "read planar x, y coordinates instead of latitudes and longitudes")
+	flag.BoolVar(&flags.Strict, "strict", flags.Strict, "reject blank and '#' comment lines in the input")
+	flag.StringVar(&flags.Solver, "solver", flags.Solver, "solver: ga (genetic algorithm), sa (simulated annealing), tabu (tabu search), exact (Held-Karp), or auto (exact for small inputs, else ga)")
+	flag.IntVar(&flags.ExactThreshold, "exact-threshold", flags.ExactThreshold, "largest number of cities the auto solver solves exactly")
+	flag.IntVar(&flags.Workers, "workers", flags.Workers, "number of GA islands run in parallel")
+	flag.IntVar(&flags.Population, "population", flags.Population, "number of tours in each population")
+	flag.IntVar(&flags.Offspring, "offspring", flags.Offspring, "number of children bred per generation (even)")
+	flag.DurationVar(&flags.Duration, "duration", flags.Duration, "time limit for the search")
+	flag.Int64Var(&flags.Seed, "seed", flags.Seed, "random seed for reproducible runs (0 seeds from the clock)")
+	flag.StringVar(&flags.Selection, "selection", flags.Selection, "selection operator: uniform, tournament, roulette, sus, or boltzmann")
+	flag.IntVar(&flags.TournamentSize, "tournament-size", flags.TournamentSize, "number of tours sampled per tournament")
+	flag.Float64Var(&flags.BoltzmannTemperature, "boltzmann-temperature", flags.BoltzmannTemperature, "start temperature for Boltzmann selection")
+	flag.Float64Var(&flags.BoltzmannCooling, "boltzmann-cooling", flags.BoltzmannCooling, "Boltzmann selection temperature multiplier per generation")
+	flag.StringVar(&flags.Crossover, "crossover", flags.Crossover, "crossover operator: prefix, ox, pmx, cx, or erx")
+	flag.Float64Var(&flags.CrossoverRate, "crossover-rate", flags.CrossoverRate, "probability that selected parents breed")
+	flag.StringVar(&flags.Mutation, "mutation", flags.Mutation, "mutation operator: inversion, insertion, scramble, or random")
+	flag.Float64Var(&flags.MutationRate, "mutation-rate", flags.MutationRate, "probability that a child is mutated")
+	flag.StringVar(&flags.Replacement, "replacement", flags.Replacement, "replacement strategy: generational, steady-state, or crowding")
+	flag.BoolVar(&flags.AdaptiveMutation, "adaptive-mutation", flags.AdaptiveMutation, "raise the mutation rate while the best score stagnates")
+	flag.Float64Var(&flags.MinMutationRate, "min-mutation-rate", flags.MinMutationRate, "lower bound of an adaptive mutation rate")
+	flag.Float64Var(&flags.MaxMutationRate, "max-mutation-rate", flags.MaxMutationRate, "upper bound of an adaptive mutation rate")
+	flag.BoolVar(&flags.CheckChildren, "validate", flags.CheckChildren, "debug mode: check that every child is a valid tour")
+	flag.BoolVar(&flags.LocalSearch, "local-search", flags.LocalSearch, "optimize every child with 2-opt and Or-opt")
+	flag.IntVar(&flags.Neighbors, "neighbors", flags.Neighbors, "nearest neighbors of each city considered by local search (0 considers all)")
+	flag.Float64Var(&flags.HeuristicFraction, "heuristic-fraction", flags.HeuristicFraction, "fraction of the initial population built by heuristics")
+	flag.Float64Var(&flags.Annealing.Temperature, "sa-temperature", flags.Annealing.Temperature, "start temperature for simulated annealing")
+	flag.Float64Var(&flags.Annealing.Cooling, "sa-cooling", flags.Annealing.Cooling, "temperature multiplier per simulated annealing iteration")
+	flag.IntVar(&flags.Annealing.Iterations, "sa-iterations", flags.Annealing.Iterations, "number of simulated annealing iterations")
+	flag.IntVar(&flags.Tabu.Tenure, "tabu-tenure", flags.Tabu.Tenure, "iterations for which the edges removed by a tabu search move may not be added back")
+	flag.IntVar(&flags.Tabu.Iterations, "tabu-iterations", flags.Tabu.Iterations, "number of tabu search iterations")
+	flag.IntVar(&flags.MaxGenerations, "max-generations", flags.MaxGenerations, "stop after this many generations (0 is unlimited)")
+	flag.IntVar(&flags.Stagnation, "stagnation", flags.Stagnation, "stop after this many generations without improvement (0 never stops)")
+	flag.IntVar(&flags.Top, "top", flags.Top, "print this many best distinct tours when done")
+	flag.Float64Var(&flags.Optimum, "optimum", flags.Optimum, "known optimal score, to report the gap of the best tour (0 if unknown)")
+	flag.Float64Var(&flags.Target, "target", flags.Target, "stop once the best tour is within this percentage of the optimum (0 never stops)")
+	flag.IntVar(&flags.Elitism, "elitism", flags.Elitism, "number of best tours protected from replacement each generation")
+	flag.IntVar(&flags.MigrationInterval, "migration-interval", flags.MigrationInterval, "generations between migrations of tours between islands (0 never migrates)")
+	flag.IntVar(&flags.Migrants, "migrants", flags.Migrants, "number of best tours each island sends per migration")
+	flag.StringVar(&flags.MigrationTopology, "migration-topology", flags.MigrationTopology, "islands each island migrates tours to: ring, full, or random")
+	flag.IntVar(&flags.BroadcastInterval, "broadcast-interval", flags.BroadcastInterval, "generations between injections of the global best tour into lagging islands (0 never injects)")
+	flag.IntVar(&flags.CheckpointInterval, "checkpoint-interval", flags.CheckpointInterval, "generations between checkpoints of each island with -checkpoint (0 uses 100)")
+	flag.Float64Var(&flags.RestartDiversity, "restart-diversity", flags.RestartDiversity, "restart islands whose diversity falls below this (0 never restarts)")
+	flag.IntVar(&flags.RestartStagnation, "restart-stagnation", flags.RestartStagnation, "restart islands after this many generations without improvement (0 never restarts)")
+	flag.Float64Var(&flags.RestartKeep, "restart-keep", flags.RestartKeep, "fraction of the best tours kept when an island restarts")
+	flag.StringVar(&flags.Start, "start", flags.Start, "name of the city every tour starts at")
+	flag.StringVar(&flags.End, "end", flags.End, "name of the city every tour ends at")
+	flag.BoolVar(&flags.Open, "open", flags.Open, "find an open path that does not return to the start city")
+	flag.Parse()
+
+	cfg := tsp.DefaultConfig()
+	if *file != "" {
+		var err error
+		if cfg, err = tsp.LoadConfig(*file); err != nil {
+			return cfg, err
+		}
+	}
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "input":
+			cfg.Input = flags.Input
+		case "output":
+			cfg.Output = flags.Output
+		case "planar":
+			cfg.Planar = flags.Planar
+		case "strict":
+			cfg.Strict = flags.Strict
+		case "solver":
+			cfg.Solver = flags.Solver
+		case "exact-threshold":
+			cfg.ExactThreshold = flags.ExactThreshold
+		case "workers":
+			cfg.Workers = flags.Workers
+		case "population":
+			cfg.Population = flags.Population
+		case "offspring":
+			cfg.Offspring = flags.Offspring
+		case "duration":
+			cfg.Duration = flags.Duration
+		case "seed":
+			cfg.Seed = flags.Seed
+		case "selection":
+			cfg.Selection = flags.Selection
+		case "tournament-size":
+			cfg.TournamentSize = flags.TournamentSize
+		case "boltzmann-temperature":
+			cfg.BoltzmannTemperature = flags.BoltzmannTemperature
+		case "boltzmann-cooling":
+			cfg.BoltzmannCooling = flags.BoltzmannCooling
+		case "crossover":
+			cfg.Crossover = flags.Crossover
+		case "crossover-rate":
+			cfg.CrossoverRate = flags.CrossoverRate
+		case "mutation":
+			cfg.Mutation = flags.Mutation
+		case "mutation-rate":
+			cfg.MutationRate = flags.MutationRate
+		case "replacement":
+			cfg.Replacement = flags.Replacement
+		case "adaptive-mutation":
+			cfg.AdaptiveMutation = flags.AdaptiveMutation
+		case "min-mutation-rate":
+			cfg.MinMutationRate = flags.MinMutationRate
+		case "max-mutation-rate":
+			cfg.MaxMutationRate = flags.MaxMutationRate
+		case "validate":
+			cfg.CheckChildren = flags.CheckChildren
+		case "local-search":
+			cfg.LocalSearch = flags.LocalSearch
+		case "neighbors":
+			cfg.Neighbors = flags.Neighbors
+		case "heuristic-fraction":
+			cfg.HeuristicFraction = flags.HeuristicFraction
+		case "sa-temperature":
+			cfg.Annealing.Temperature = flags.Annealing.Temperature
+		case "sa-cooling":
+			cfg.Annealing.Cooling = flags.Annealing.Cooling
+		case "sa-iterations":
+			cfg.Annealing.Iterations = flags.Annealing.Iterations
+		case "tabu-tenure":
+			cfg.Tabu.Tenure = flags.Tabu.Tenure
+		case "tabu-iterations":
+			cfg.Tabu.Iterations = flags.Tabu.Iterations
+		case "max-generations":
+			cfg.MaxGenerations = flags.MaxGenerations
+		case "stagnation":
+			cfg.Stagnation = flags.Stagnation
+		case "top":
+			cfg.Top = flags.Top
+		case "optimum":
+			cfg.Optimum = flags.Optimum
+		case "target":
+			cfg.Target = flags.Target
+		case "elitism":
+			cfg.Elitism = flags.Elitism
+		case "migration-interval":
+			cfg.MigrationInterval = flags.MigrationInterval
+		case "migrants":
+			cfg.Migrants = flags.Migrants
+		case "migration-topology":
+			cfg.MigrationTopology = flags.MigrationTopology
+		case "broadcast-interval":
+			cfg.BroadcastInterval = flags.BroadcastInterval
+		case "checkpoint-interval":
+			cfg.CheckpointInterval = flags.CheckpointInterval
+		case "restart-diversity":
+			cfg.RestartDiversity = flags.RestartDiversity
+		case "restart-stagnation":
+			cfg.RestartStagnation = flags.RestartStagnation
+		case "restart-keep":
+			cfg.RestartKeep = flags.RestartKeep
+		case "start":
+			cfg.Start = flags.Start
+		case "end":
+			cfg.End = flags.End
+		case "open":
+			cfg.Open = flags.Open
+		}
+	})
+	if cfg.Input == "" {
+		cfg.Input = defaultInput
+	}
+	return cfg, cfg.Validate()
+}
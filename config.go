@@ -0,0 +1,346 @@
+package tsp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime"
+	"time"
+)
+
+// Config holds the parameters of a run. Input is the file of cities to tour,
+// which is read by the command (Solve takes an already loaded genotype), and
+// Output is an optional file the command writes the best tour to. Planar input
+// has x, y coordinates instead of latitudes and longitudes, and Strict input
+// may not contain blank or comment lines. The command resolves values in order
+// of precedence: defaults < config file < command-line flags.
+//
+// OnImprovement, if set, is called by Solve with each new best tour, and
+// OnGeneration with the statistics of every generation. If Top is positive,
+// Solve keeps the Top best distinct tours reported by any island, and passes
+// them to OnTop when done. Logger, if set, receives info events for new best
+// tours and debug events for termination.
+//
+// Terminator, if set, is checked by every GA island after each generation,
+// and stops the island as well as MaxGenerations and Stagnation, which (if
+// positive) stop it like the terminators of the same names.
+//
+// Selector, Crossoverer and Mutator, if set, replace the selection, crossover
+// and mutation operators named by Selection, Crossover and Mutation.
+//
+// With a positive Neighbors, the 2-opt moves of local search only consider
+// edges to the Neighbors nearest cities of each city, which is much faster for
+// large inputs.
+//
+// Every CheckpointInterval generations (if positive), each GA island passes a
+// snapshot of its population to OnCheckpoint, with the latest snapshots of the
+// other islands. With Resume, island i starts from the population of snapshot i
+// (modulo the number of snapshots) and its generation count, instead of random
+// tours.
+//
+// CheckChildren is a debug mode that checks every child bred by the GA is a
+// valid tour (panicking if not).
+//
+// With the auto Solver, Solve finds an optimal tour by HeldKarp when there are
+// at most ExactThreshold cities (and no start or end city), and runs the GA
+// otherwise. Workers is the number of GA islands run in parallel by Solve.
+//
+// Optimum is the known optimal score of the cities (0 if unknown), in the units
+// of Tour.Score. With a positive Target, the GA stops once its best tour is
+// within Target percent of the optimum.
+//
+// Start and End optionally name the cities that every GA tour must visit first
+// and last (the other solvers cannot honor them). Open makes tours paths that
+// do not return to their first city, so the wrap-around edge is not scored.
+//
+// With AdaptiveMutation, the mutation rate of each GA island rises while its
+// best score stagnates and decays while it improves, within [MinMutationRate,
+// MaxMutationRate].
+//
+// Every MigrationInterval generations (if positive), each GA island sends
+// copies of its Migrants best tours to its neighbors in the MigrationTopology
+// (the next island of a ring, every other island, or a random other island),
+// where they replace the worst tours. Every BroadcastInterval generations (if
+// positive), each island lagging behind the best tour found by any island takes
+// in a copy of it.
+//
+// Boltzmann selection starts at BoltzmannTemperature, which each GA island
+// multiplies by BoltzmannCooling every generation, so selection pressure rises
+// over the run.
+//
+// An island restarts when its diversity falls below RestartDiversity, or its
+// best score has not improved for RestartStagnation generations (either check
+// is off when zero): the RestartKeep fraction of its best tours (and the elite)
+// are kept, and the rest are replaced by random tours.
+type Config struct {
+	Input                string            `json:"input"`
+	Output               string            `json:"output"`
+	Planar               bool              `json:"planar"`
+	Strict               bool              `json:"strict"`
+	Solver               string            `json:"solver"`
+	ExactThreshold       int               `json:"exact_threshold"`
+	Workers              int               `json:"workers"`
+	Population           int               `json:"population"`
+	Offspring            int               `json:"offspring"`
+	Duration             time.Duration     `json:"-"`
+	Seed                 int64             `json:"seed"`
+	MaxGenerations       int               `json:"max_generations"`
+	Stagnation           int               `json:"stagnation"`
+	Top                  int               `json:"top"`
+	Optimum              float64           `json:"optimum"`
+	Target               float64           `json:"target"`
+	Selection            string            `json:"selection"`
+	TournamentSize       int               `json:"tournament_size"`
+	BoltzmannTemperature float64           `json:"boltzmann_temperature"`
+	BoltzmannCooling     float64           `json:"boltzmann_cooling"`
+	Crossover            string            `json:"crossover"`
+	CrossoverRate        float64           `json:"crossover_rate"`
+	Mutation             string            `json:"mutation"`
+	MutationRate         float64           `json:"mutation_rate"`
+	Replacement          string            `json:"replacement"`
+	AdaptiveMutation     bool              `json:"adaptive_mutation"`
+	MinMutationRate      float64           `json:"min_mutation_rate"`
+	MaxMutationRate      float64           `json:"max_mutation_rate"`
+	LocalSearch          bool              `json:"local_search"`
+	Neighbors            int               `json:"neighbors"`
+	CheckChildren        bool              `json:"check_children"`
+	Elitism              int               `json:"elitism"`
+	MigrationInterval    int               `json:"migration_interval"`
+	Migrants             int               `json:"migrants"`
+	MigrationTopology    string            `json:"migration_topology"`
+	BroadcastInterval    int               `json:"broadcast_interval"`
+	CheckpointInterval   int               `json:"checkpoint_interval"`
+	RestartDiversity     float64           `json:"restart_diversity"`
+	RestartStagnation    int               `json:"restart_stagnation"`
+	RestartKeep          float64           `json:"restart_keep"`
+	Start                string            `json:"start"`
+	End                  string            `json:"end"`
+	Open                 bool              `json:"open"`
+	HeuristicFraction    float64           `json:"heuristic_fraction"`
+	Annealing            SAOptions         `json:"annealing"`
+	Tabu                 TabuOptions       `json:"tabu"`
+	OnImprovement        func(Tour, Stats) `json:"-"`
+	OnGeneration         func(Stats)       `json:"-"`
+	OnTop                func([]Tour)      `json:"-"`
+	OnCheckpoint         func([]Snapshot)  `json:"-"`
+	Resume               []Snapshot        `json:"-"`
+	Terminator           Terminator        `json:"-"`
+	Selector             Selector          `json:"-"`
+	Crossoverer          Crossoverer       `json:"-"`
+	Mutator              Mutator           `json:"-"`
+	Logger               *slog.Logger      `json:"-"`
+}
+
+// DefaultConfig returns the default GA parameters.
+func DefaultConfig() Config {
+	return Config{
+		Solver:               GeneticSolver,
+		ExactThreshold:       12,
+		Workers:              max(2, runtime.NumCPU()/2+1),
+		Population:           100,
+		Offspring:            10,
+		Duration:             10 * time.Second,
+		Selection:            UniformSelection,
+		TournamentSize:       3,
+		BoltzmannTemperature: 1000,
+		BoltzmannCooling:     0.999,
+		Crossover:            PrefixCrossover,
+		CrossoverRate:        0.9,
+		Mutation:             InversionMutation,
+		MutationRate:         0.1,
+		Replacement:          GenerationalReplacement,
+		MinMutationRate:      0.01,
+		MaxMutationRate:      0.5,
+		Migrants:             2,
+		MigrationTopology:    RingTopology,
+		RestartKeep:          0.1,
+		Annealing: SAOptions{
+			Temperature: 1000,
+			Cooling:     0.99999,
+			Iterations:  1000000,
+		},
+		Tabu: TabuOptions{
+			Tenure:     10,
+			Iterations: 1000,
+		},
+	}
+}
+
+// Return the configured logger, or one that discards every event.
+func (c Config) logger() *slog.Logger {
+	if c.Logger == nil {
+		return slog.New(slog.DiscardHandler)
+	}
+	return c.Logger
+}
+
+// LoadConfig reads GA parameters from a JSON file. Parameters missing from the
+// file keep their default values.
+func LoadConfig(path string) (Config, error) {
+	cfg := DefaultConfig()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	err = json.Unmarshal(data, &cfg)
+	return cfg, err
+}
+
+// UnmarshalJSON decodes a config, reading the duration as a string like "30s".
+func (c *Config) UnmarshalJSON(data []byte) error {
+	type config Config
+	v := struct {
+		*config
+		Duration string `json:"duration"`
+	}{config: (*config)(c)}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.Duration != "" {
+		duration, err := time.ParseDuration(v.Duration)
+		if err != nil {
+			return err
+		}
+		c.Duration = duration
+	}
+	return nil
+}
+
+// Validate checks that the GA parameters can be evolved.
+func (c Config) Validate() error {
+	switch c.Solver {
+	case GeneticSolver, AnnealingSolver, TabuSolver, ExactSolver, AutoSolver:
+	default:
+		return fmt.Errorf("Unknown solver: %s", c.Solver)
+	}
+	if (c.Start != "" || c.End != "") && c.Solver != GeneticSolver && c.Solver != AutoSolver {
+		return fmt.Errorf("Start and end cities need the GA solver, not %s", c.Solver)
+	}
+	if c.ExactThreshold < 0 || c.ExactThreshold > MaxHeldKarpCities {
+		return fmt.Errorf("Exact threshold must be between 0 and %d", MaxHeldKarpCities)
+	}
+	if c.Workers < 1 {
+		return errors.New("Workers must be at least 1")
+	}
+	if c.Population < 2 {
+		return errors.New("Population must be at least 2")
+	}
+	if c.Offspring < 0 || c.Offspring%2 != 0 {
+		return errors.New("Offspring must be a non-negative even number")
+	}
+	if c.Offspring > c.Population {
+		return errors.New("Offspring must not exceed population")
+	}
+	if c.Duration <= 0 {
+		return errors.New("Duration must be positive")
+	}
+	if c.MaxGenerations < 0 {
+		return errors.New("Max generations must be non-negative")
+	}
+	if c.Stagnation < 0 {
+		return errors.New("Stagnation must be non-negative")
+	}
+	if c.Top < 0 {
+		return errors.New("Top must be non-negative")
+	}
+	if c.Optimum < 0 {
+		return errors.New("Optimum must be non-negative")
+	}
+	if c.Target < 0 || c.Target > 0 && c.Optimum == 0 {
+		return errors.New("Target must be non-negative, and needs an optimum")
+	}
+	switch c.Selection {
+	case UniformSelection, TournamentSelection, RouletteSelection, SUSSelection, BoltzmannSelection:
+	default:
+		return fmt.Errorf("Unknown selection operator: %s", c.Selection)
+	}
+	if c.TournamentSize < 1 {
+		return errors.New("Tournament size must be at least 1")
+	}
+	if c.BoltzmannTemperature <= 0 {
+		return errors.New("Boltzmann temperature must be positive")
+	}
+	if c.BoltzmannCooling <= 0 || c.BoltzmannCooling > 1 {
+		return errors.New("Boltzmann cooling must be in (0, 1]")
+	}
+	switch c.Crossover {
+	case PrefixCrossover, OrderCrossover, PMXCrossover, CycleCrossover, EdgeCrossover:
+	default:
+		return fmt.Errorf("Unknown crossover operator: %s", c.Crossover)
+	}
+	if c.CrossoverRate < 0 || c.CrossoverRate > 1 {
+		return errors.New("Crossover rate must be between 0 and 1")
+	}
+	switch c.Mutation {
+	case InversionMutation, InsertionMutation, ScrambleMutation, RandomMutation:
+	default:
+		return fmt.Errorf("Unknown mutation operator: %s", c.Mutation)
+	}
+	if c.MutationRate < 0 || c.MutationRate > 1 {
+		return errors.New("Mutation rate must be between 0 and 1")
+	}
+	switch c.Replacement {
+	case GenerationalReplacement, SteadyStateReplacement, CrowdingReplacement:
+	default:
+		return fmt.Errorf("Unknown replacement strategy: %s", c.Replacement)
+	}
+	if c.AdaptiveMutation && (c.MinMutationRate < 0 || c.MinMutationRate > c.MaxMutationRate || c.MaxMutationRate > 1) {
+		return errors.New("Mutation rate bounds must satisfy 0 <= min <= max <= 1")
+	}
+	if c.CheckpointInterval < 0 {
+		return errors.New("Checkpoint interval must be non-negative")
+	}
+	if c.Neighbors < 0 {
+		return errors.New("Neighbors must be non-negative")
+	}
+	if c.Elitism < 0 || c.Elitism >= c.Population {
+		return errors.New("Elitism must be non-negative and less than population")
+	}
+	if c.MigrationInterval < 0 {
+		return errors.New("Migration interval must be non-negative")
+	}
+	switch c.MigrationTopology {
+	case RingTopology, FullTopology, RandomTopology:
+	default:
+		return fmt.Errorf("Unknown migration topology: %s", c.MigrationTopology)
+	}
+	if c.BroadcastInterval < 0 {
+		return errors.New("Broadcast interval must be non-negative")
+	}
+	if c.Migrants < 0 || c.MigrationInterval > 0 && c.Migrants >= c.Population {
+		return errors.New("Migrants must be non-negative and less than population")
+	}
+	if c.RestartDiversity < 0 || c.RestartDiversity > 1 {
+		return errors.New("Restart diversity must be between 0 and 1")
+	}
+	if c.RestartStagnation < 0 {
+		return errors.New("Restart stagnation must be non-negative")
+	}
+	if c.RestartKeep < 0 || c.RestartKeep > 1 {
+		return errors.New("Restart keep fraction must be between 0 and 1")
+	}
+	if c.Start != "" && c.Start == c.End {
+		return errors.New("Start and end cities must differ")
+	}
+	if c.HeuristicFraction < 0 || c.HeuristicFraction > 1 {
+		return errors.New("Heuristic fraction must be between 0 and 1")
+	}
+	if c.Annealing.Temperature <= 0 {
+		return errors.New("Annealing temperature must be positive")
+	}
+	if c.Annealing.Cooling <= 0 || c.Annealing.Cooling > 1 {
+		return errors.New("Annealing cooling must be in (0, 1]")
+	}
+	if c.Annealing.Iterations < 0 {
+		return errors.New("Annealing iterations must be non-negative")
+	}
+	if c.Tabu.Tenure < 0 {
+		return errors.New("Tabu tenure must be non-negative")
+	}
+	if c.Tabu.Iterations < 0 {
+		return errors.New("Tabu iterations must be non-negative")
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package tsp
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Return the index of the named city in a tour, or -1 if it is not visited.
+func (t Tour) find(name string) int {
+	return slices.IndexFunc(t.path, func(i int) bool {
+		return t.cities[i].Name == name
+	})
+}
+
+// Repair a tour so that the named start city is visited first and the named
+// end city is visited last. An empty name leaves that end of the tour free.
+func (t *Tour) anchor(start, end string) {
+	if i := t.find(start); start != "" && i > 0 {
+		// Rotating a closed tour does not change its score
+		t.rotate(i)
+		t.dirty = t.dirty || t.open
+	}
+	if i, last := t.find(end), len(t.path)-1; end != "" && i >= 0 && i < last {
+		city := t.path[i]
+		copy(t.path[i:], t.path[i+1:])
+		t.path[last], t.dirty = city, true
+	}
+}
+
+// Repair every tour of a population to start and end at the cities
+// named in the config.
+func (p *Population) anchor(cfg Config) {
+	if cfg.Start == "" && cfg.End == "" {
+		return
+	}
+	for i := range p.solutions {
+		p.solutions[i].anchor(cfg.Start, cfg.End)
+	}
+}
+
+// Check that the start and end cities named in a config are in the genotype.
+func (gt Genotype) checkAnchors(cfg Config) error {
+	for _, name := range []string{cfg.Start, cfg.End} {
+		if name != "" && !slices.ContainsFunc(gt.genes, func(c City) bool { return c.Name == name }) {
+			return fmt.Errorf("Unknown city: %s", name)
+		}
+	}
+	return nil
+}
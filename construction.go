@@ -0,0 +1,99 @@
+package tsp
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// NearestNeighborTour builds a tour greedily, starting from the city at the
+// given index and always moving to the closest unvisited city.
+func (gt Genotype) NearestNeighborTour(start int) (tour Tour) {
+	tour = gt.emptyTour()
+	dist := tour.metric()
+	n := len(gt.genes)
+	tour.path = make([]int, 0, n)
+	visited := make([]bool, n)
+	for current := start; current >= 0; {
+		tour.path = append(tour.path, current)
+		visited[current] = true
+		next, nearest := -1, math.MaxFloat64
+		for i := range n {
+			if d := dist(current, i); !visited[i] && d < nearest {
+				next, nearest = i, d
+			}
+		}
+		current = next
+	}
+	return
+}
+
+// An edge between the cities at two indices.
+type edge struct {
+	i, j int
+	dist float64
+}
+
+// GreedyTour builds a tour with the greedy edge heuristic. Edges are added from
+// shortest to longest, skipping any that would give a city a third edge or
+// close a cycle early, until the edges form a single path through every city
+// (which the tour closes).
+func (gt Genotype) GreedyTour() (tour Tour) {
+	tour = gt.emptyTour()
+	dist := tour.metric()
+	n := len(gt.genes)
+	if n == 0 {
+		return
+	}
+	var edges []edge
+	for i := range n {
+		for j := i + 1; j < n; j++ {
+			edges = append(edges, edge{i, j, dist(i, j)})
+		}
+	}
+	slices.SortFunc(edges, func(a, b edge) int {
+		return cmp.Compare(a.dist, b.dist)
+	})
+
+	// Union-find over path fragments detects edges that would close a cycle
+	fragment := make([]int, n)
+	for i := range fragment {
+		fragment[i] = i
+	}
+	find := func(i int) int {
+		for fragment[i] != i {
+			fragment[i] = fragment[fragment[i]]
+			i = fragment[i]
+		}
+		return i
+	}
+	adjacent := make([][]int, n)
+	for added, k := 0, 0; added < n-1; k++ {
+		e := edges[k]
+		if len(adjacent[e.i]) == 2 || len(adjacent[e.j]) == 2 {
+			continue
+		}
+		fi, fj := find(e.i), find(e.j)
+		if fi == fj {
+			continue
+		}
+		fragment[fi] = fj
+		adjacent[e.i] = append(adjacent[e.i], e.j)
+		adjacent[e.j] = append(adjacent[e.j], e.i)
+		added++
+	}
+
+	// Walk the path from one of its ends
+	current := slices.IndexFunc(adjacent, func(a []int) bool { return len(a) < 2 })
+	for prev := -1; current >= 0; {
+		tour.path = append(tour.path, current)
+		next := -1
+		for _, c := range adjacent[current] {
+			if c != prev {
+				next = c
+			}
+		}
+		prev, current = current, next
+	}
+	return
+}
@@ -0,0 +1,174 @@
+package tsp
+
+import (
+	"math/rand"
+	"slices"
+)
+
+// Names of the crossover operators.
+const (
+	PrefixCrossover = "prefix"
+	OrderCrossover  = "ox"
+	PMXCrossover    = "pmx"
+	CycleCrossover  = "cx"
+	EdgeCrossover   = "erx"
+)
+
+// CrossoverOX is the order crossover (OX1) operator. Each child copies the
+// segment between two cut points from one parent, then fills the remaining
+// positions with the cities of the other parent in order, starting after the
+// second cut.
+func (t Tour) CrossoverOX(rng *rand.Rand, other Tour) []Tour {
+	mn, mx := randRange(rng, len(t.path))
+	return []Tour{orderChild(t, other, mn, mx), orderChild(other, t, mn, mx)}
+}
+
+// Create an OX1 child from the segment [mn, mx] of one parent.
+func orderChild(t1, t2 Tour, mn, mx int) (child Tour) {
+	n := len(t1.path)
+	child = t1.emptyTour()
+	child.path = make([]int, n)
+	used := make([]bool, n)
+	for i := mn; i <= mx; i++ {
+		child.path[i] = t1.path[i]
+		used[t1.path[i]] = true
+	}
+	j := (mx + 1) % n
+	for k := range n {
+		city := t2.path[(mx+1+k)%n]
+		if !used[city] {
+			child.path[j] = city
+			j = (j + 1) % n
+		}
+	}
+	return
+}
+
+// CrossoverPMX is the partially-mapped crossover operator. Each child copies the
+// segment between two cut points from one parent, and takes the remaining
+// positions from the other parent, following the mapping between the two
+// segments wherever a city is already in the child.
+func (t Tour) CrossoverPMX(rng *rand.Rand, other Tour) []Tour {
+	mn, mx := randRange(rng, len(t.path))
+	return []Tour{mappedChild(t, other, mn, mx), mappedChild(other, t, mn, mx)}
+}
+
+// Create a PMX child from the segment [mn, mx] of one parent.
+func mappedChild(t1, t2 Tour, mn, mx int) (child Tour) {
+	n := len(t1.path)
+	child = t1.emptyTour()
+	child.path = make([]int, n)
+	pos := make([]int, n)
+	used := make([]bool, n)
+	for i, city := range t1.path {
+		pos[city] = i
+	}
+	for i := mn; i <= mx; i++ {
+		child.path[i] = t1.path[i]
+		used[t1.path[i]] = true
+	}
+	for i, city := range t2.path {
+		if i >= mn && i <= mx {
+			continue
+		}
+		for used[city] {
+			city = t2.path[pos[city]]
+		}
+		child.path[i] = city
+	}
+	return
+}
+
+// CrossoverCX is the cycle crossover operator. The positions of the parents are
+// split into cycles, and each child takes its cities from alternating parents
+// cycle by cycle, so every city keeps its position in one of the parents.
+func (t Tour) CrossoverCX(other Tour) []Tour {
+	n := len(t.path)
+	c0, c1 := t.emptyTour(), other.emptyTour()
+	c0.path, c1.path = make([]int, n), make([]int, n)
+	pos := make([]int, n)
+	for i, city := range t.path {
+		pos[city] = i
+	}
+	done := make([]bool, n)
+	for start, cycle := 0, 0; start < n; start++ {
+		if done[start] {
+			continue
+		}
+		for i := start; !done[i]; i = pos[other.path[i]] {
+			done[i] = true
+			if cycle%2 == 0 {
+				c0.path[i], c1.path[i] = t.path[i], other.path[i]
+			} else {
+				c0.path[i], c1.path[i] = other.path[i], t.path[i]
+			}
+		}
+		cycle++
+	}
+	return []Tour{c0, c1}
+}
+
+// CrossoverERX is the edge recombination crossover operator. Each child starts
+// at the first city of one parent and repeatedly moves to the neighbor (in
+// either parent) that has the fewest remaining neighbors of its own, so the
+// child preserves as many parent edges as possible. When the current city has
+// no neighbors left, the child moves to a random unvisited city.
+func (t Tour) CrossoverERX(rng *rand.Rand, other Tour) []Tour {
+	return []Tour{edgeChild(rng, t, other), edgeChild(rng, other, t)}
+}
+
+// Create an ERX child starting from the first city of one parent.
+func edgeChild(rng *rand.Rand, t1, t2 Tour) (child Tour) {
+	n := len(t1.path)
+	edges := make([][]int, n)
+	for _, parent := range []Tour{t1, t2} {
+		for i, city := range parent.path {
+			for _, neighbor := range []int{parent.path[(i+n-1)%n], parent.path[(i+1)%n]} {
+				if neighbor != city && !slices.Contains(edges[city], neighbor) {
+					edges[city] = append(edges[city], neighbor)
+				}
+			}
+		}
+	}
+	child = t1.emptyTour()
+	child.path = make([]int, 0, n)
+	visited := make([]bool, n)
+	for current := t1.path[0]; ; {
+		child.path = append(child.path, current)
+		visited[current] = true
+		if len(child.path) == n {
+			return
+		}
+		for _, neighbor := range edges[current] {
+			edges[neighbor] = slices.DeleteFunc(edges[neighbor], func(c int) bool {
+				return c == current
+			})
+		}
+		next, ties := -1, 0
+		for _, neighbor := range edges[current] {
+			switch {
+			case next == -1 || len(edges[neighbor]) < len(edges[next]):
+				next, ties = neighbor, 1
+			case len(edges[neighbor]) == len(edges[next]):
+				if ties++; rng.Intn(ties) == 0 {
+					next = neighbor
+				}
+			}
+		}
+		if next == -1 {
+			next = randomUnvisited(rng, visited)
+		}
+		current = next
+	}
+}
+
+// Return a random index that has not been visited.
+func randomUnvisited(rng *rand.Rand, visited []bool) int {
+	var unvisited []int
+	for i, v := range visited {
+		if !v {
+			unvisited = append(unvisited, i)
+		}
+	}
+	return unvisited[rng.Intn(len(unvisited))]
+}
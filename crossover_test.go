@@ -0,0 +1,56 @@
+package tsp
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestOperatorPermutations(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, n := range []int{2, 3, 5, 20} {
+		gt := randomGenotype(rng, n, false, false)
+		for trial := range 50 {
+			t1, t2 := gt.RandomTour(rng), gt.RandomTour(rng)
+			for _, op := range []string{PrefixCrossover, OrderCrossover, PMXCrossover, CycleCrossover, EdgeCrossover} {
+				children := t1.crossover(rng, t2, op)
+				if len(children) == 0 {
+					t.Fatalf("n=%d %s crossover: no children", n, op)
+				}
+				for _, child := range children {
+					if err := child.Validate(gt); err != nil {
+						t.Fatalf("n=%d trial %d %s crossover of %v and %v: %s", n, trial, op, t1.path, t2.path, err)
+					}
+				}
+			}
+			for _, op := range []string{InversionMutation, InsertionMutation, ScrambleMutation} {
+				tour := t1.Clone()
+				tour.mutate(rng, op, 1)
+				if err := tour.Validate(gt); err != nil {
+					t.Fatalf("n=%d trial %d %s mutation of %v: %s", n, trial, op, t1.path, err)
+				}
+			}
+		}
+	}
+}
+
+func TestCrossoverCX(t *testing.T) {
+	// The positions split into the cycles {0, 3, 6, 7}, {1, 2, 4} and {5}, and
+	// the children take them from alternating parents
+	gt := randomGenotype(rand.New(rand.NewSource(1)), 8, false, false)
+	t1, t2 := gt.emptyTour(), gt.emptyTour()
+	t1.path, t2.path = []int{0, 1, 2, 3, 4, 5, 6, 7}, []int{7, 4, 1, 0, 2, 5, 3, 6}
+	children := t1.CrossoverCX(t2)
+	for i, want := range [][]int{{0, 4, 1, 3, 2, 5, 6, 7}, {7, 1, 2, 0, 4, 5, 3, 6}} {
+		if got := children[i].path; !slices.Equal(got, want) {
+			t.Errorf("child %d: %v, want %v", i, got, want)
+		}
+	}
+	// Each position of identical parents is a cycle of its own
+	children = t1.CrossoverCX(t1.Clone())
+	for i, child := range children {
+		if !slices.Equal(child.path, t1.path) {
+			t.Errorf("child %d of identical parents: %v", i, child.path)
+		}
+	}
+}
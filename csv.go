@@ -0,0 +1,47 @@
+package tsp
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+)
+
+// InitCSV initializes the search space from a 'name,lat,lon' CSV file with a
+// header row. Quoted fields may contain commas and spaces.
+func (gt *Genotype) InitCSV(file string) error {
+	reader, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	records := csv.NewReader(reader)
+	records.TrimLeadingSpace = true
+	if _, err := records.Read(); err == io.EOF {
+		return gt.checkCities()
+	} else if err != nil {
+		return err
+	}
+	for {
+		record, err := records.Read()
+		if err == io.EOF {
+			if err := gt.checkCities(); err != nil {
+				return err
+			}
+			gt.index()
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		city, err := initCity(record)
+		if err == nil {
+			err = gt.checkCity(city)
+		}
+		if err != nil {
+			row, _ := records.FieldPos(0)
+			return fmt.Errorf("Row %d: %w", row, err)
+		}
+		gt.genes = append(gt.genes, city)
+	}
+}
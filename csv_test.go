@@ -0,0 +1,44 @@
+package tsp
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInitCSV(t *testing.T) {
+	for _, test := range []struct {
+		input string
+		names string // The names of the cities read, comma separated
+		err   string // Part of the error wanted, if any
+	}{
+		{input: "name,lat,lon\nA,1,2\nB, 3, 4\n", names: "A,B"},
+		{input: "name,lat,lon\n\"Washington, D.C.\",38.9,-77.0\nNew York,40.7,-74.0\n", names: "Washington, D.C.,New York"},
+		{input: "", err: "No cities found"},
+		{input: "name,lat,lon\n", err: "No cities found"},
+		{input: "name,lat,lon\nA,1,2\nA,3,4\n", err: "Duplicate cities: A"},
+		{input: "name,lat,lon\nA,1,2\nB,x,4\n", err: "Row 3: strconv.ParseFloat: parsing \"x\": invalid syntax"},
+		{input: "name,lat,lon\nA,1,2\nB,3,200\n", err: "Row 3: Longitude out of range [-180, 180]: 200"},
+		{input: "name,lat,lon\nA,1,2\nB,3\n", err: "wrong number of fields"},
+		{input: "name,lat,lon\n\"A,1,2\n", err: "extraneous or missing \" in quoted-field"},
+	} {
+		file := filepath.Join(t.TempDir(), "cities.csv")
+		if err := os.WriteFile(file, []byte(test.input), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		gt := Genotype{}
+		err := gt.InitCSV(file)
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("%q: error %v, want %s", test.input, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %s", test.input, err)
+		} else if got := strings.Join(cityNames(gt), ","); got != test.names {
+			t.Errorf("%q: read %s, want %s", test.input, got, test.names)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package tsp
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// The largest numbers of cities solved exactly. The table of HeldKarp has
+// n * 2^n entries, and BruteForce tries (n-1)! tours.
+const (
+	MaxHeldKarpCities   = 16
+	MaxBruteForceCities = 10
+)
+
+// HeldKarp finds an optimal tour through the cities of a genotype with the Held
+// and Karp dynamic program, in O(2^n n^2) time. It returns an error for more
+// than MaxHeldKarpCities cities.
+func HeldKarp(gt Genotype) (Tour, error) {
+	n := len(gt.genes)
+	if n > MaxHeldKarpCities {
+		return Tour{}, fmt.Errorf("Too many cities for an exact solve: %d > %d", n, MaxHeldKarpCities)
+	}
+	tour := gt.emptyTour()
+	if n == 0 {
+		return tour, nil
+	}
+	dist := tour.metric()
+
+	// cost[set][j] is the length of the shortest path through the set of cities
+	// that ends at city j. Closed tours start at city 0, open paths anywhere.
+	full := 1<<n - 1
+	cost, parent := make([][]float64, full+1), make([][]int, full+1)
+	for set := range cost {
+		cost[set], parent[set] = make([]float64, n), make([]int, n)
+		for j := range n {
+			cost[set][j], parent[set][j] = math.Inf(1), -1
+		}
+	}
+	for j := range n {
+		if j == 0 || gt.open {
+			cost[1<<j][j] = 0
+		}
+	}
+	for set := 1; set <= full; set++ {
+		for j := range n {
+			if set&(1<<j) == 0 || math.IsInf(cost[set][j], 1) {
+				continue
+			}
+			for k := range n {
+				if set&(1<<k) != 0 {
+					continue
+				}
+				next := set | 1<<k
+				if c := cost[set][j] + dist(j, k); c < cost[next][k] {
+					cost[next][k], parent[next][k] = c, j
+				}
+			}
+		}
+	}
+
+	// Close the tour back at city 0, then follow the parents back from the end
+	last, best := 0, math.Inf(1)
+	for j := range n {
+		c := cost[full][j]
+		if !gt.open {
+			c += dist(j, 0)
+		}
+		if c < best {
+			last, best = j, c
+		}
+	}
+	for set, j := full, last; j >= 0; set, j = set&^(1<<j), parent[set][j] {
+		tour.path = append(tour.path, j)
+	}
+	slices.Reverse(tour.path)
+	return tour, nil
+}
+
+// BruteForce finds an optimal tour through the cities of a genotype by scoring
+// every permutation of them (fixing the first city of closed tours, since
+// rotations have the same score). It returns an error for more than
+// MaxBruteForceCities cities.
+func BruteForce(gt Genotype) (Tour, error) {
+	n := len(gt.genes)
+	if n > MaxBruteForceCities {
+		return Tour{}, fmt.Errorf("Too many cities for a brute force solve: %d > %d", n, MaxBruteForceCities)
+	}
+	tour := gt.emptyTour()
+	tour.path = make([]int, n)
+	for i := range tour.path {
+		tour.path[i] = i
+	}
+	best, bestScore := slices.Clone(tour.path), math.Inf(1)
+	first := 1
+	if gt.open {
+		first = 0
+	}
+	var permute func(k int)
+	permute = func(k int) {
+		if k >= n-1 {
+			tour.dirty = true
+			if score := tour.Score(); score < bestScore {
+				copy(best, tour.path)
+				bestScore = score
+			}
+			return
+		}
+		for i := k; i < n; i++ {
+			tour.path[k], tour.path[i] = tour.path[i], tour.path[k]
+			permute(k + 1)
+			tour.path[k], tour.path[i] = tour.path[i], tour.path[k]
+		}
+	}
+	permute(first)
+	tour.path, tour.dirty = best, true
+	return tour, nil
+}
@@ -0,0 +1,54 @@
+package tsp
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestHeldKarpBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, open := range []bool{false, true} {
+		for _, asym := range []bool{false, true} {
+			for trial := range 20 {
+				name := fmt.Sprintf("open=%t asym=%t trial=%d", open, asym, trial)
+				gt := randomGenotype(rng, 6, open, asym)
+				hk, err := HeldKarp(gt)
+				if err != nil {
+					t.Fatalf("%s: %s", name, err)
+				}
+				bf, err := BruteForce(gt)
+				if err != nil {
+					t.Fatalf("%s: %s", name, err)
+				}
+				for _, tour := range []Tour{hk, bf} {
+					if err := tour.Validate(gt); err != nil {
+						t.Fatalf("%s: %s", name, err)
+					}
+				}
+				if math.Abs(hk.Score()-bf.Score()) > 1e-9 {
+					t.Errorf("%s: HeldKarp score %f, BruteForce score %f", name, hk.Score(), bf.Score())
+				}
+			}
+		}
+	}
+}
+
+func TestHeldKarpRectangle(t *testing.T) {
+	// Four corners of a 3 by 4 rectangle and a point inside it: the optimal tour
+	// visits the inner point between two adjacent corners. From (0, 0) via
+	// (1, 2) to (0, 4), it is 2*sqrt(5) + 3 + 4 + 3 = 14.472...
+	gt := Genotype{planar: true}
+	for _, c := range [][2]float64{{0, 0}, {3, 0}, {3, 4}, {0, 4}, {1, 2}} {
+		gt.genes = append(gt.genes, City{Name: fmt.Sprint(c), Lat: c[0], Lon: c[1]})
+	}
+	gt.index()
+	best, err := HeldKarp(gt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 2*math.Sqrt(5) + 10; math.Abs(best.Score()-want) > 1e-9 {
+		t.Errorf("score %f, want %f", best.Score(), want)
+	}
+}
@@ -0,0 +1,58 @@
+package tsp
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// A GeoJSON feature collection.
+type featureCollection struct {
+	Type     string    `json:"type"`
+	Features []feature `json:"features"`
+}
+
+// A GeoJSON feature.
+type feature struct {
+	Type       string         `json:"type"`
+	Geometry   geometry       `json:"geometry"`
+	Properties map[string]any `json:"properties"`
+}
+
+// A GeoJSON geometry. Coordinates are a [lon, lat] position for a Point, or a
+// list of positions for a LineString.
+type geometry struct {
+	Type        string `json:"type"`
+	Coordinates any    `json:"coordinates"`
+}
+
+// Return the GeoJSON [lon, lat] position of a city.
+func (c City) position() []float64 {
+	return []float64{c.Lon, c.Lat}
+}
+
+// WriteGeoJSON writes a tour to a writer as a GeoJSON feature collection: a
+// LineString through the cities, closed back at the start city unless the tour
+// is open, followed by a named Point feature for each city.
+func (t Tour) WriteGeoJSON(w io.Writer) error {
+	cities := t.Cities()
+	route := make([][]float64, 0, len(cities)+1)
+	for _, city := range cities {
+		route = append(route, city.position())
+	}
+	if len(cities) > 0 && !t.open {
+		route = append(route, cities[0].position())
+	}
+	features := []feature{{
+		Type:       "Feature",
+		Geometry:   geometry{"LineString", route},
+		Properties: map[string]any{"score": t.Score()},
+	}}
+	for _, city := range cities {
+		features = append(features, feature{
+			Type:       "Feature",
+			Geometry:   geometry{"Point", city.position()},
+			Properties: map[string]any{"name": city.Name},
+		})
+	}
+	return json.NewEncoder(w).Encode(featureCollection{"FeatureCollection", features})
+}
@@ -0,0 +1,41 @@
+package tsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"testing"
+)
+
+func TestWriteGeoJSONOpen(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, open := range []bool{false, true} {
+		gt := randomGenotype(rng, 5, open, false)
+		tour := gt.RandomTour(rng)
+		var buf bytes.Buffer
+		if err := tour.WriteGeoJSON(&buf); err != nil {
+			t.Fatal(err)
+		}
+		var v struct {
+			Features []struct {
+				Geometry struct {
+					Coordinates json.RawMessage `json:"coordinates"`
+				} `json:"geometry"`
+			} `json:"features"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &v); err != nil {
+			t.Fatal(err)
+		}
+		var route [][]float64
+		if err := json.Unmarshal(v.Features[0].Geometry.Coordinates, &route); err != nil {
+			t.Fatal(err)
+		}
+		want := 6
+		if open {
+			want = 5
+		}
+		if len(route) != want {
+			t.Errorf("open=%t: route has %d positions, want %d", open, len(route), want)
+		}
+	}
+}
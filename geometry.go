@@ -0,0 +1,68 @@
+package tsp
+
+import (
+	"math"
+	"slices"
+)
+
+// BoundingBox returns the smallest and largest latitudes and longitudes of the
+// cities of a genotype (all zero if there are none).
+func (gt Genotype) BoundingBox() (minLat, minLon, maxLat, maxLon float64) {
+	if len(gt.genes) == 0 {
+		return
+	}
+	minLon, minLat, maxLon, maxLat = bounds(gt.genes)
+	return
+}
+
+// Centroid returns a city at the mean latitude and longitude of the cities of
+// a genotype (the zero city if there are none). It is the centre of mass of
+// planar coordinates, and a rough centre for geographic ones.
+func (gt Genotype) Centroid() (centroid City) {
+	if len(gt.genes) == 0 {
+		return
+	}
+	for _, city := range gt.genes {
+		centroid.Lat += city.Lat
+		centroid.Lon += city.Lon
+	}
+	centroid.Name = "Centroid"
+	centroid.Lat /= float64(len(gt.genes))
+	centroid.Lon /= float64(len(gt.genes))
+	return
+}
+
+// Return the smallest and largest longitudes and latitudes of a list of cities.
+func bounds(cities []City) (minLon, minLat, maxLon, maxLat float64) {
+	minLon, maxLon = math.MaxFloat64, -math.MaxFloat64
+	minLat, maxLat = math.MaxFloat64, -math.MaxFloat64
+	for _, city := range cities {
+		minLon, maxLon = min(minLon, city.Lon), max(maxLon, city.Lon)
+		minLat, maxLat = min(minLat, city.Lat), max(maxLat, city.Lat)
+	}
+	return
+}
+
+// Normalize rescales the coordinates of the cities of a genotype into the unit
+// square, shifting the bounding box to the origin and dividing by its longer
+// side, so relative distances (and so the best tours under euclidean distance)
+// are unchanged. Normalized coordinates are no longer geographic, so the
+// genotype is made planar and scored by euclidean distance, and the distance
+// matrix is recomputed.
+func (gt *Genotype) Normalize() {
+	if len(gt.genes) == 0 {
+		return
+	}
+	gt.planar, gt.dist = true, euclidean
+	minLat, minLon, maxLat, maxLon := gt.BoundingBox()
+	scale := max(maxLat-minLat, maxLon-minLon)
+	if scale == 0 {
+		scale = 1
+	}
+	gt.genes = slices.Clone(gt.genes)
+	for i := range gt.genes {
+		gt.genes[i].Lat = (gt.genes[i].Lat - minLat) / scale
+		gt.genes[i].Lon = (gt.genes[i].Lon - minLon) / scale
+	}
+	gt.index()
+}
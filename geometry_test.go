@@ -0,0 +1,63 @@
+package tsp
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	gt := randomGenotype(rng, 20, false, false)
+	cities := slices.Clone(gt.genes)
+	minLat, minLon, maxLat, maxLon := gt.BoundingBox()
+	scale := max(maxLat-minLat, maxLon-minLon)
+	gt.Normalize()
+	if gt.Geographic() {
+		t.Error("normalized cities are geographic")
+	}
+	minLat, minLon, maxLat, maxLon = gt.BoundingBox()
+	if minLat != 0 || minLon != 0 || max(maxLat, maxLon) != 1 {
+		t.Errorf("normalized bounding box (%f, %f) to (%f, %f), want the unit square", minLat, minLon, maxLat, maxLon)
+	}
+	for i := range cities {
+		for j := range cities {
+			if got, want := gt.matrix[i][j], euclidean(cities[i], cities[j])/scale; math.Abs(got-want) > 1e-12 {
+				t.Fatalf("distance from %d to %d is %f, want %f", i, j, got, want)
+			}
+		}
+	}
+
+	// Tours rank as they do by euclidean distance between the original cities
+	planar := Genotype{}
+	planar.SetPlanar(true)
+	if err := planar.InitCities(cities); err != nil {
+		t.Fatal(err)
+	}
+	for range 20 {
+		a, b := gt.RandomTour(rng), gt.RandomTour(rng)
+		pa, pb := planar.emptyTour(), planar.emptyTour()
+		pa.path, pb.path = a.path, b.path
+		if (a.Score() < b.Score()) != (pa.Score() < pb.Score()) {
+			t.Fatalf("normalized scores %f and %f rank differently from euclidean scores %f and %f", a.Score(), b.Score(), pa.Score(), pb.Score())
+		}
+	}
+}
+
+func TestBoundingBox(t *testing.T) {
+	gt, err := NewGenotype([]City{{Name: "A", Lat: 10, Lon: -20}, {Name: "B", Lat: -5, Lon: 40}, {Name: "C", Lat: 25, Lon: 10}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if minLat, minLon, maxLat, maxLon := gt.BoundingBox(); minLat != -5 || minLon != -20 || maxLat != 25 || maxLon != 40 {
+		t.Errorf("bounding box (%f, %f) to (%f, %f), want (-5, -20) to (25, 40)", minLat, minLon, maxLat, maxLon)
+	}
+	if c := gt.Centroid(); math.Abs(c.Lat-10) > 1e-9 || math.Abs(c.Lon-10) > 1e-9 {
+		t.Errorf("centroid (%f, %f), want (10, 10)", c.Lat, c.Lon)
+	}
+	empty := Genotype{}
+	if minLat, minLon, maxLat, maxLon := empty.BoundingBox(); minLat != 0 || minLon != 0 || maxLat != 0 || maxLon != 0 || empty.Centroid() != (City{}) {
+		t.Error("empty genotype has a bounding box or centroid")
+	}
+}
@@ -0,0 +1,92 @@
+package tsp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// The JSON representation of a city.
+type cityJSON struct {
+	Name string  `json:"name"`
+	Lat  float64 `json:"lat"`
+	Lon  float64 `json:"lon"`
+}
+
+// The JSON representation of a tour.
+type tourJSON struct {
+	Path  []City  `json:"path"`
+	Score float64 `json:"score"`
+}
+
+// MarshalJSON encodes a city as a {"name","lat","lon"} object.
+func (c City) MarshalJSON() ([]byte, error) {
+	return json.Marshal(cityJSON{c.Name, c.Lat, c.Lon})
+}
+
+// UnmarshalJSON decodes a city from a {"name","lat","lon"} object.
+func (c *City) UnmarshalJSON(data []byte) error {
+	var v cityJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*c = City{Name: v.Name, Lat: v.Lat, Lon: v.Lon}
+	return nil
+}
+
+// MarshalJSON encodes a tour as its ordered path plus the total score.
+func (t Tour) MarshalJSON() ([]byte, error) {
+	return json.Marshal(tourJSON{t.Cities(), t.Score()})
+}
+
+// UnmarshalJSON decodes a tour from its ordered path. The score is recomputed
+// from the path by great circle distance rather than trusted, so a round trip
+// only keeps the score of a closed tour of a geographic search space. Use
+// LoadTourJSON to decode a tour scored like the tours of a genotype.
+func (t *Tour) UnmarshalJSON(data []byte) error {
+	var v tourJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*t = Tour{cities: v.Path, path: make([]int, len(v.Path)), dirty: true}
+	for i := range t.path {
+		t.path[i] = i
+	}
+	return nil
+}
+
+// LoadTourJSON reads a tour written by WriteJSON through the cities of a
+// genotype, so it is scored (and open or closed) like the tours of the genotype.
+func LoadTourJSON(r io.Reader, gt Genotype) (Tour, error) {
+	var v tourJSON
+	if err := json.NewDecoder(r).Decode(&v); err != nil {
+		return Tour{}, err
+	}
+	index := make(map[string]int, len(gt.genes))
+	for i, city := range gt.genes {
+		index[city.Name] = i
+	}
+	tour := gt.emptyTour()
+	for _, city := range v.Path {
+		i, ok := index[city.Name]
+		if !ok {
+			return Tour{}, fmt.Errorf("Unknown city: %s", city.Name)
+		}
+		tour.path = append(tour.path, i)
+	}
+	return tour, tour.Validate(gt)
+}
+
+// WriteJSON writes a JSON version of a tour to a writer.
+func (t Tour) WriteJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(t)
+}
+
+// InitJSON initializes the search space from a JSON array of cities.
+func (gt *Genotype) InitJSON(r io.Reader) error {
+	var cities []City
+	if err := json.NewDecoder(r).Decode(&cities); err != nil {
+		return err
+	}
+	return gt.InitCities(cities)
+}
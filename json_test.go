@@ -0,0 +1,68 @@
+package tsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestTourJSONRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	gt := randomGenotype(rng, 8, false, false)
+	tour := gt.RandomTour(rng)
+	data, err := json.Marshal(tour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Tour
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := names(decoded), names(tour); !slices.Equal(got, want) {
+		t.Errorf("decoded cities %v, want %v", got, want)
+	}
+	if math.Abs(decoded.Score()-tour.Score()) > 1e-9 {
+		t.Errorf("decoded score %f, want %f", decoded.Score(), tour.Score())
+	}
+}
+
+func TestLoadTourJSON(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, gt := range []Genotype{
+		randomGenotype(rng, 8, true, false),
+		randomGenotype(rng, 8, false, true),
+		func() Genotype {
+			gt := randomGenotype(rng, 8, false, false)
+			gt.SetPlanar(true)
+			gt.index()
+			return gt
+		}(),
+	} {
+		tour := gt.RandomTour(rng)
+		var buf bytes.Buffer
+		if err := tour.WriteJSON(&buf); err != nil {
+			t.Fatal(err)
+		}
+		decoded, err := LoadTourJSON(&buf, gt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(decoded.path, tour.path) || math.Abs(decoded.Score()-tour.Score()) > 1e-9 {
+			t.Errorf("decoded %v with score %f, want %v with score %f", decoded.path, decoded.Score(), tour.path, tour.Score())
+		}
+	}
+	if _, err := LoadTourJSON(bytes.NewBufferString(`{"path": [{"name": "nowhere"}]}`), randomGenotype(rng, 3, false, false)); err == nil {
+		t.Error("decoded a tour through an unknown city")
+	}
+}
+
+// Return the names of the cities of a tour, in order.
+func names(tour Tour) (names []string) {
+	for _, city := range tour.Cities() {
+		names = append(names, city.Name)
+	}
+	return
+}
@@ -0,0 +1,241 @@
+package tsp
+
+import (
+	"math"
+	"slices"
+)
+
+// Moves must shorten a tour by more than this to count as an improvement, so
+// floating point noise can't make local search cycle.
+const minGain = 1e-9
+
+// TwoOpt is the 2-opt local search. It repeatedly reverses segments of the tour
+// that shorten it, until no improving reversal is left. Cities wait in a queue
+// to have the edges to their predecessor and successor checked against every
+// other edge; a city whose edges cannot be improved drops out (its don't-look
+// bit is set) until a reversal changes its edges, so converged parts of the
+// tour (such as those a child inherits from locally optimal parents) are not
+// searched again. Since a reversal also turns around edges it leaves in place,
+// which can make a bit stale, an empty queue is confirmed by a sweep of every
+// pair of edges, which queues the cities of any reversal it makes. Reversing a
+// segment of an asymmetric tour changes the length of every edge in it, which
+// would leave no bits set, so asymmetric tours are always swept. An open tour
+// may also reverse a prefix, replacing its unscored wrap-around edge, so its
+// first city can move as well as its last.
+func (t *Tour) TwoOpt() {
+	if t.asym {
+		t.twoOptSweep()
+	} else {
+		t.twoOpt()
+	}
+}
+
+// Run TwoOpt with don't-look bits, and return the number of distances it
+// looked up.
+func (t *Tour) twoOpt() (evaluations int) {
+	n := len(t.path)
+	position := make([]int, len(t.cities))
+	queued := make([]bool, len(t.cities))
+	for i, city := range t.path {
+		position[city], queued[city] = i, true
+	}
+	queue := slices.Clone(t.path)
+	// Reverse the cities between the edges after indices lo and hi, and queue
+	// the cities whose edges changed
+	reverse := func(lo, hi int, delta float64) {
+		slices.Reverse(t.path[lo+1 : hi+1])
+		t.score += delta
+		for k := lo + 1; k <= hi; k++ {
+			position[t.path[k]] = k
+		}
+		for _, k := range []int{(lo + n) % n, lo + 1, hi, (hi + 1) % n} {
+			if c := t.path[k]; !queued[c] {
+				queue, queued[c] = append(queue, c), true
+			}
+		}
+	}
+	for len(queue) > 0 {
+		for len(queue) > 0 {
+			city := queue[0]
+			queue, queued[city] = queue[1:], false
+			lo, hi, delta, k := t.improvingReversal(position[city])
+			if evaluations += k; delta < -minGain {
+				reverse(lo, hi, delta)
+			}
+		}
+		for i := t.firstEdge(); i < n-2; i++ {
+			for j := i + 2; j < n; j++ {
+				if i <= 0 && j == n-1 {
+					continue
+				}
+				evaluations += 4
+				if delta := t.inversionDelta(i+1, j); delta < -minGain {
+					reverse(i, j, delta)
+				}
+			}
+		}
+	}
+	return
+}
+
+// Run TwoOpt by sweeping every pair of edges until no reversal improves the
+// tour, and return the number of distances looked up.
+func (t *Tour) twoOptSweep() (evaluations int) {
+	n := len(t.path)
+	for improved := true; improved; {
+		improved = false
+		for i := t.firstEdge(); i < n-2; i++ {
+			for j := i + 2; j < n; j++ {
+				if i <= 0 && j == n-1 {
+					continue
+				}
+				evaluations += 4
+				if delta := t.inversionDelta(i+1, j); delta < -minGain {
+					slices.Reverse(t.path[i+1 : j+1])
+					t.score += delta
+					improved = true
+				}
+			}
+		}
+	}
+	return
+}
+
+// Return the index of the first edge that a reversal may follow: -1 for an
+// open tour, whose prefix may be reversed (replacing its unscored wrap-around
+// edge), otherwise 0.
+func (t Tour) firstEdge() int {
+	if t.open {
+		return -1
+	}
+	return 0
+}
+
+// Find a reversal that shortens the tour, between the edges after indices
+// lo < hi, one of which is the edge out of or into the city a at index i. A
+// reversal replacing the edge from a to b can only shorten the tour if a is
+// nearer its new neighbor than b, or if the other new edge is shorter than the
+// edge it replaces (and that is found from the other edge), so other reversals
+// are skipped with one distance lookup. Reversals of an open tour that replace
+// its wrap-around edge are always evaluated, including prefix reversals (lo is
+// -1). It returns the change in score (non-negative if there is none), and the
+// number of distances looked up.
+func (t *Tour) improvingReversal(i int) (lo, hi int, delta float64, evaluations int) {
+	dist := t.metric()
+	n := len(t.path)
+	a := t.path[i]
+	for _, edge := range []int{i, (i - 1 + n) % n} {
+		// The neighbor of a across the edge, and the index of the city that
+		// would become its neighbor, relative to the other edge
+		b, side := t.path[(i+1)%n], 0
+		if edge != i {
+			b, side = t.path[edge], 1
+		}
+		wrap := t.unscored(edge + 1)
+		ab := dist(a, b)
+		evaluations++
+		for other := range n {
+			lo, hi = min(edge, other), max(edge, other)
+			if t.open && hi == n-1 && 0 < lo && lo < n-1 {
+				evaluations += 4
+				if delta = t.inversionDelta(0, lo); delta < -minGain {
+					return -1, lo, delta, evaluations
+				}
+			}
+			if hi-lo < 2 || lo == 0 && hi == n-1 {
+				continue
+			}
+			if !wrap && !t.unscored(other+1) {
+				evaluations++
+				if dist(a, t.path[(other+side)%n]) >= ab {
+					continue
+				}
+			}
+			evaluations += 4
+			if delta = t.inversionDelta(lo+1, hi); delta < -minGain {
+				return
+			}
+		}
+	}
+	return 0, 0, 0, evaluations
+}
+
+// OrOpt is the Or-opt local search move. It looks for a chain of up to
+// maxSegment consecutive cities that can be moved (forwards or reversed) to a
+// position between two other cities so that the tour gets shorter, and makes
+// the first such move found. It reports whether a move was made, so it can be
+// run to convergence in a loop. An open tour is searched as a closed tour
+// through an extra city at no distance from the others, which stands for its
+// ends, so chains can move to or from either end. Segments of an asymmetric
+// tour are never reversed.
+func (t *Tour) OrOpt(maxSegment int) bool {
+	dist, path := t.metric(), t.path
+	if t.open {
+		metric := dist
+		dist = func(a, b int) float64 {
+			if a < 0 || b < 0 {
+				return 0
+			}
+			return metric(a, b)
+		}
+		path = append(slices.Clone(t.path), -1)
+	}
+	n := len(path)
+	for size := 1; size <= min(maxSegment, n-3); size++ {
+		for i := range n {
+			// The extra city of an open tour stays in place
+			if t.open && (n-1-i+n)%n < size {
+				continue
+			}
+			prev, first := path[(i-1+n)%n], path[i]
+			last, next := path[(i+size-1)%n], path[(i+size)%n]
+			gain := dist(prev, first) + dist(last, next) - dist(prev, next)
+			for k := range n - size - 1 {
+				c, e := path[(i+size+k)%n], path[(i+size+k+1)%n]
+				forward := dist(c, first) + dist(last, e) - dist(c, e)
+				reversed := math.Inf(1)
+				if !t.asym {
+					reversed = dist(c, last) + dist(first, e) - dist(c, e)
+				}
+				if cost := min(forward, reversed); gain-cost > minGain {
+					path = moveSegment(path, i, size, k, reversed < forward)
+					if t.open {
+						// Drop the extra city, so the path starts after it
+						end := slices.Index(path, -1)
+						path = append(append(t.path[:0], path[end+1:]...), path[:end]...)
+					}
+					t.path = path
+					t.score -= gain - cost
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// Move the segment of the given size starting at index i of a path, so it
+// follows the k-th city after the segment (optionally reversing it).
+func moveSegment(path []int, i, size, k int, reverse bool) []int {
+	rotated := append(slices.Clone(path[i:]), path[:i]...)
+	segment, rest := rotated[:size], rotated[size:]
+	if reverse {
+		slices.Reverse(segment)
+	}
+	moved := path[:0]
+	moved = append(moved, rest[:k+1]...)
+	moved = append(moved, segment...)
+	return append(moved, rest[k+1:]...)
+}
+
+// Optimize a tour with 2-opt and Or-opt moves until neither improves it.
+func (t *Tour) localSearch() {
+	twoOpt := t.TwoOpt
+	if t.neighbors != nil {
+		twoOpt = func() { t.TwoOptNeighbors(t.neighbors) }
+	}
+	twoOpt()
+	for t.OrOpt(3) {
+		twoOpt()
+	}
+}
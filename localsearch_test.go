@@ -0,0 +1,203 @@
+package tsp
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// Return a genotype of n cities with random coordinates, scored by a random
+// asymmetric matrix if asym is set.
+func randomGenotype(rng *rand.Rand, n int, open, asym bool) Genotype {
+	gt := Genotype{}
+	for i := range n {
+		gt.genes = append(gt.genes, City{Name: fmt.Sprint(i), Lat: rng.Float64()*160 - 80, Lon: rng.Float64()*340 - 170})
+	}
+	gt.index()
+	if asym {
+		matrix := make([][]float64, n)
+		for i := range matrix {
+			matrix[i] = make([]float64, n)
+			for j := range matrix[i] {
+				if i != j {
+					matrix[i][j] = 1 + rng.Float64()*100
+				}
+			}
+		}
+		gt.SetMatrix(matrix)
+	}
+	gt.SetOpen(open)
+	return gt
+}
+
+// Return an open genotype of n planar cities on a line, one apart, so the
+// shortest tour visits them in order.
+func lineGenotype(t *testing.T, n int) Genotype {
+	t.Helper()
+	gt := Genotype{}
+	gt.SetPlanar(true)
+	var cities []City
+	for i := range n {
+		cities = append(cities, City{Name: fmt.Sprint(i), Lon: float64(i)})
+	}
+	if err := gt.InitCities(cities); err != nil {
+		t.Fatal(err)
+	}
+	gt.SetOpen(true)
+	return gt
+}
+
+// Check that the cached score of a tour matches a full recompute.
+func checkScore(t *testing.T, tour Tour, context string) {
+	t.Helper()
+	if got, want := tour.Score(), tour.length(); math.Abs(got-want) > 1e-6 {
+		t.Fatalf("%s: cached score %f, recomputed %f", context, got, want)
+	}
+}
+
+func TestOrOptDelta(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, n := range []int{5, 6, 10, 20} {
+		for _, open := range []bool{false, true} {
+			for _, asym := range []bool{false, true} {
+				gt := randomGenotype(rng, n, open, asym)
+				for trial := range 50 {
+					tour := gt.RandomTour(rng)
+					tour.Score()
+					for moves := 0; tour.OrOpt(3); moves++ {
+						checkScore(t, tour, fmt.Sprintf("n=%d open=%t asym=%t trial=%d move=%d", n, open, asym, trial, moves))
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestTwoOptDelta(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, n := range []int{5, 10, 50} {
+		for _, open := range []bool{false, true} {
+			for _, asym := range []bool{false, true} {
+				gt := randomGenotype(rng, n, open, asym)
+				for trial := range 20 {
+					tour := gt.RandomTour(rng)
+					tour.Score()
+					tour.TwoOpt()
+					checkScore(t, tour, fmt.Sprintf("n=%d open=%t asym=%t trial=%d", n, open, asym, trial))
+				}
+			}
+		}
+	}
+}
+
+func TestOpenScore(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	gt := randomGenotype(rng, 10, false, false)
+	tour := gt.RandomTour(rng)
+	path := tour.Clone()
+	path.open, path.dirty = true, true
+	wrap := gt.matrix[tour.path[len(tour.path)-1]][tour.path[0]]
+	if got, want := path.Score(), tour.Score()-wrap; math.Abs(got-want) > 1e-9 {
+		t.Fatalf("open score %f, want closed score less the wrap-around edge %f", got, want)
+	}
+}
+
+func TestOpenEnds(t *testing.T) {
+	// Each path is one move from the shortest, which moves its first or last
+	// city, so the move must replace the unscored wrap-around edge
+	gt := lineGenotype(t, 6)
+	for _, search := range []struct {
+		name string
+		run  func(*Tour)
+	}{
+		{"2-opt", (*Tour).TwoOpt},
+		{"2-opt neighbors", func(tour *Tour) { tour.TwoOptNeighbors(gt.NeighborLists(5)) }},
+		{"Or-opt", func(tour *Tour) {
+			for tour.OrOpt(3) {
+			}
+		}},
+	} {
+		for _, path := range [][]int{{2, 1, 0, 3, 4, 5}, {1, 0, 2, 3, 4, 5}, {0, 1, 2, 3, 5, 4}} {
+			tour := gt.emptyTour()
+			tour.path = slices.Clone(path)
+			search.run(&tour)
+			checkScore(t, tour, fmt.Sprintf("%s from %v", search.name, path))
+			if got := tour.Score(); math.Abs(got-5) > 1e-9 {
+				t.Errorf("%s from %v: score %f, want 5", search.name, path, got)
+			}
+		}
+	}
+}
+
+// Report whether any reversal would shorten a tour.
+func improvable(tour Tour) bool {
+	n := len(tour.path)
+	for i := tour.firstEdge(); i < n-2; i++ {
+		for j := i + 2; j < n; j++ {
+			if !(i <= 0 && j == n-1) && tour.inversionDelta(i+1, j) < -minGain {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestTwoOptQuality(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, n := range []int{4, 5, 10, 50, 200} {
+		for _, open := range []bool{false, true} {
+			for _, asym := range []bool{false, true} {
+				gt := randomGenotype(rng, n, open, asym)
+				for range 10 {
+					tour := gt.RandomTour(rng)
+					sweep := tour.Clone()
+					tour.TwoOpt()
+					sweep.twoOptSweep()
+					for _, tour := range []Tour{tour, sweep} {
+						if err := tour.Validate(gt); err != nil {
+							t.Fatal(err)
+						}
+						if improvable(tour) {
+							t.Fatalf("n=%d open=%t asym=%t: tour has an improving reversal", n, open, asym)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// Benchmark 2-opt with and without don't-look bits on random tours, and on
+// children of 2-opt optimal parents, reporting the distances looked up.
+func BenchmarkTwoOpt(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	gt := randomGenotype(rng, 500, false, false)
+	random := gt.RandomTour(rng)
+	p1, p2 := gt.RandomTour(rng), gt.RandomTour(rng)
+	p1.TwoOpt()
+	p2.TwoOpt()
+	child := makeChild(rng, p1, p2)
+	for _, start := range []struct {
+		name string
+		tour Tour
+	}{{"random", random}, {"child", child}} {
+		for _, search := range []struct {
+			name string
+			run  func(*Tour) int
+		}{{"dont-look", (*Tour).twoOpt}, {"sweep", (*Tour).twoOptSweep}} {
+			b.Run(start.name+"/"+search.name, func(b *testing.B) {
+				b.ReportAllocs()
+				evaluations := 0
+				b.ResetTimer()
+				for range b.N {
+					tour := start.tour.Clone()
+					tour.Score()
+					evaluations += search.run(&tour)
+				}
+				b.ReportMetric(float64(evaluations)/float64(b.N), "lookups/op")
+			})
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package tsp
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// InitMatrix initializes the search space from a distance matrix instead of
+// coordinates. The first line names the n cities, and each of the next n lines
+// is a row of the distances from one city to every city, in the same order.
+// Tours are scored by the matrix, which may be asymmetric.
+func (gt *Genotype) InitMatrix(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	lines := func() (fields []string, ok bool) {
+		for scanner.Scan() {
+			if line := scanner.Text(); gt.strict || !isComment(line) {
+				return strings.Fields(line), true
+			}
+		}
+		return nil, false
+	}
+	names, ok := lines()
+	if !ok {
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+		return errors.New("No cities found")
+	}
+	var matrix [][]float64
+	for fields, ok := lines(); ok; fields, ok = lines() {
+		row := make([]float64, len(fields))
+		for j, field := range fields {
+			d, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				return fmt.Errorf("Matrix row %d: %w", len(matrix)+1, err)
+			}
+			row[j] = d
+		}
+		matrix = append(matrix, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	for _, name := range names {
+		gt.genes = append(gt.genes, City{Name: name})
+	}
+	if err := gt.checkCities(); err != nil {
+		return err
+	}
+	return gt.SetMatrix(matrix)
+}
@@ -0,0 +1,36 @@
+package tsp
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInitMatrix(t *testing.T) {
+	gt := Genotype{}
+	input := "A B C\n0 1 2\n# Comment\n3 0 4\n5 6 0\n"
+	if err := gt.InitMatrix(strings.NewReader(input)); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(cityNames(gt), ","); got != "A,B,C" || !gt.asym || gt.Geographic() {
+		t.Fatalf("read %s (asymmetric %t, geographic %t), want asymmetric A,B,C", got, gt.asym, gt.Geographic())
+	}
+	tour := gt.emptyTour()
+	tour.path = []int{0, 1, 2}
+	if got := tour.Score(); got != 1+4+5 {
+		t.Errorf("tour A,B,C scores %f, want 10", got)
+	}
+	for _, test := range []struct {
+		input, err string
+	}{
+		{"", "No cities found"},
+		{"A A\n0 1\n1 0\n", "Duplicate cities: A"},
+		{"A B\n0 1\n", "Matrix has 1 rows, expected 2"},
+		{"A B\n0 1\n1\n", "Matrix row 2 has 1 columns, expected 2"},
+		{"A B\n0 1\n1 x\n", "Matrix row 2: strconv.ParseFloat: parsing \"x\": invalid syntax"},
+	} {
+		gt := Genotype{}
+		if err := gt.InitMatrix(strings.NewReader(test.input)); err == nil || err.Error() != test.err {
+			t.Errorf("%q: error %v, want %s", test.input, err, test.err)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package tsp
+
+import "math/rand"
+
+// Names of the migration topologies.
+const (
+	RingTopology   = "ring"
+	FullTopology   = "full"
+	RandomTopology = "random"
+)
+
+// The index and migration channels of a GA island: its inbox of immigrants, and
+// the inboxes of the islands it may send emigrants to. All islands share the
+// tracker of the global best tour.
+type migration struct {
+	id       int
+	in       <-chan []Tour
+	out      []chan<- []Tour
+	topology string
+	global   *BestTracker
+}
+
+// Connect n islands in a topology. In a ring, each island sends emigrants to
+// the next island; fully connected islands send them to every other island,
+// and random islands to one other island picked at random for each migration.
+// A lone island has no neighbors to migrate to.
+func connect(n int, topology string) []migration {
+	global := &BestTracker{}
+	inboxes := make([]chan []Tour, n)
+	for i := range inboxes {
+		inboxes[i] = make(chan []Tour, max(1, n-1))
+	}
+	islands := make([]migration, n)
+	for i := range islands {
+		islands[i] = migration{id: i, topology: topology, global: global}
+		if n == 1 {
+			continue
+		}
+		islands[i].in = inboxes[i]
+		if topology == RingTopology {
+			islands[i].out = []chan<- []Tour{inboxes[(i+1)%n]}
+			continue
+		}
+		for j := range inboxes {
+			if j != i {
+				islands[i].out = append(islands[i].out, inboxes[j])
+			}
+		}
+	}
+	return islands
+}
+
+// Send copies of the k best tours of a population to the neighbors of an
+// island, then replace the worst tours with any immigrants. Neither step
+// blocks: emigrants are dropped if a neighbor's inbox is full.
+func (p *Population) migrate(rng *rand.Rand, m migration, k int) {
+	if len(m.out) == 0 {
+		return
+	}
+	out := m.out
+	if m.topology == RandomTopology {
+		i := rng.Intn(len(out))
+		out = out[i : i+1]
+	}
+	for _, inbox := range out {
+		select {
+		case inbox <- p.emigrants(k):
+		default:
+		}
+	}
+	for {
+		select {
+		case tours := <-m.in:
+			p.immigrate(tours)
+		default:
+			return
+		}
+	}
+}
+
+// Return copies of the k best tours of a population.
+func (p Population) emigrants(k int) []Tour {
+	order := p.ranked()
+	tours := make([]Tour, 0, k)
+	for _, i := range order[:min(k, len(order))] {
+		tours = append(tours, p.solutions[i].Clone())
+	}
+	return tours
+}
+
+// Replace the worst tours of a population with immigrants.
+func (p *Population) immigrate(tours []Tour) {
+	order := p.ranked()
+	for k, tour := range tours[:min(len(tours), len(order))] {
+		p.solutions[order[len(order)-1-k]] = tour
+	}
+}
@@ -0,0 +1,102 @@
+package tsp
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// Return the islands that received emigrants from a migration, emptying their
+// inboxes.
+func received(islands []migration) (ids []int) {
+	for _, m := range islands {
+		select {
+		case <-m.in:
+			ids = append(ids, m.id)
+		default:
+		}
+	}
+	return
+}
+
+func TestMigrationTopologies(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	gt := randomGenotype(rng, 10, false, false)
+	p := randomPopulation(rng, gt, 10)
+	for i := range 4 {
+		islands := connect(4, RingTopology)
+		p.migrate(rng, islands[i], 2)
+		if got := received(islands); !slices.Equal(got, []int{(i + 1) % 4}) {
+			t.Errorf("ring island %d sent to %v", i, got)
+		}
+	}
+	islands := connect(4, FullTopology)
+	if p.migrate(rng, islands[1], 2); !slices.Equal(received(islands), []int{0, 2, 3}) {
+		t.Error("fully connected island did not send to every other")
+	}
+	for range 20 {
+		islands := connect(4, RandomTopology)
+		p.migrate(rng, islands[2], 2)
+		if got := received(islands); len(got) != 1 || got[0] == 2 {
+			t.Fatalf("random island sent to %v", got)
+		}
+	}
+	if lone := connect(1, RingTopology); lone[0].in != nil || len(lone[0].out) != 0 {
+		t.Error("lone island has neighbors")
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	gt := randomGenotype(rng, 10, false, false)
+	islands := connect(2, RingTopology)
+	from, to := randomPopulation(rng, gt, 10), randomPopulation(rng, gt, 10)
+	emigrants := from.emigrants(2)
+	from.migrate(rng, islands[0], 2)
+	worst := to.ranked()[8:]
+	to.migrate(rng, islands[1], 0)
+	for k, i := range []int{worst[1], worst[0]} {
+		if !slices.Equal(to.solutions[i].path, emigrants[k].path) {
+			t.Errorf("tour %d is %v, want emigrant %v", i, to.solutions[i].path, emigrants[k].path)
+		}
+	}
+	ranked := from.ranked()
+	if &to.solutions[worst[1]].path[0] == &from.solutions[ranked[0]].path[0] {
+		t.Error("emigrant shares its path with its island")
+	}
+}
+
+func TestBestTracker(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	gt := randomGenotype(rng, 20, false, false)
+	p := randomPopulation(rng, gt, 400)
+	b := &BestTracker{}
+	if _, ok := b.Best(); ok || b.Score() != math.MaxFloat64 {
+		t.Fatal("empty tracker has a best tour")
+	}
+	var wg sync.WaitGroup
+	for w := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := w; i < len(p.solutions); i += 8 {
+				b.Update(p.solutions[i].Clone())
+			}
+		}()
+	}
+	wg.Wait()
+	want := p.Best()
+	best, ok := b.Best()
+	if !ok || b.Score() != want.Score() || best.Score() != want.Score() {
+		t.Fatalf("tracked best scores %f, want %f", b.Score(), want.Score())
+	}
+	if b.Update(want) {
+		t.Error("update with an equal tour reported an improvement")
+	}
+	best.path[0], best.path[1] = best.path[1], best.path[0]
+	if again, _ := b.Best(); slices.Equal(again.path, best.path) {
+		t.Error("best tour shares its path with the tracker")
+	}
+}
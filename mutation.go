@@ -0,0 +1,93 @@
+package tsp
+
+import "math/rand"
+
+// Names of the mutation operators.
+const (
+	InversionMutation = "inversion"
+	InsertionMutation = "insertion"
+	ScrambleMutation  = "scramble"
+	RandomMutation    = "random"
+)
+
+// MutateInsertion is the insertion mutation operator. With the given
+// probability, it removes a random city from the tour and reinserts it at
+// another random position.
+func (t *Tour) MutateInsertion(rng *rand.Rand, rate float64) {
+	if rng.Float64() < rate {
+		from, to := randRange(rng, len(t.path))
+		if rng.Intn(2) == 0 {
+			from, to = to, from
+		}
+		city := t.path[from]
+		if from < to {
+			copy(t.path[from:to], t.path[from+1:to+1])
+		} else {
+			copy(t.path[to+1:from+1], t.path[to:from])
+		}
+		t.path[to] = city
+		t.dirty = true
+	}
+}
+
+// MutateScramble is the scramble mutation operator. With the given probability,
+// it shuffles the cities of a random segment of the tour.
+func (t *Tour) MutateScramble(rng *rand.Rand, rate float64) {
+	if rng.Float64() < rate {
+		mn, mx := randRange(rng, len(t.path))
+		segment := t.path[mn : mx+1]
+		rng.Shuffle(len(segment), func(i, j int) {
+			segment[i], segment[j] = segment[j], segment[i]
+		})
+		t.dirty = true
+	}
+}
+
+// Mutate a tour with the named mutation operator. The random operator picks one
+// of the others for each tour.
+func (t *Tour) mutate(rng *rand.Rand, op string, rate float64) {
+	if op == RandomMutation {
+		op = []string{InversionMutation, InsertionMutation, ScrambleMutation}[rng.Intn(3)]
+	}
+	switch op {
+	case InsertionMutation:
+		t.MutateInsertion(rng, rate)
+	case ScrambleMutation:
+		t.MutateScramble(rng, rate)
+	default:
+		t.Mutate(rng, rate)
+	}
+}
+
+// Multipliers of an adaptive mutation rate for each generation in which the best
+// score stagnates or improves.
+const (
+	mutationGrowth = 1.1
+	mutationDecay  = 0.9
+)
+
+// An adaptive mutation rate. It rises while the best score of an island
+// stagnates, to explore more once the population converges, and decays back
+// while the best score improves.
+type adaptiveRate struct {
+	rate, min, max float64
+}
+
+// Create an adaptive mutation rate that starts at the configured rate.
+func newAdaptiveRate(cfg Config) *adaptiveRate {
+	return &adaptiveRate{
+		rate: min(max(cfg.MutationRate, cfg.MinMutationRate), cfg.MaxMutationRate),
+		min:  cfg.MinMutationRate,
+		max:  cfg.MaxMutationRate,
+	}
+}
+
+// Update the rate after a generation, and return it.
+func (a *adaptiveRate) update(improved bool) float64 {
+	if improved {
+		a.rate = max(a.min, a.rate*mutationDecay)
+	} else {
+		a.rate = min(a.max, a.rate*mutationGrowth)
+	}
+	return a.rate
+}
@@ -0,0 +1,114 @@
+package tsp
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestMutateDelta(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, n := range []int{2, 3, 4, 10, 50} {
+		for _, open := range []bool{false, true} {
+			for _, asym := range []bool{false, true} {
+				gt := randomGenotype(rng, n, open, asym)
+				tour := gt.RandomTour(rng)
+				tour.Score()
+				for i := range 200 {
+					tour.Mutate(rng, 1)
+					checkScore(t, tour, fmt.Sprintf("n=%d open=%t asym=%t mutation %d", n, open, asym, i))
+				}
+			}
+		}
+	}
+}
+
+func TestScoreCache(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, asym := range []bool{false, true} {
+		gt := randomGenotype(rng, 20, false, asym)
+		tour := gt.RandomTour(rng)
+		for _, op := range []string{InversionMutation, InsertionMutation, ScrambleMutation, RandomMutation} {
+			for i := range 50 {
+				tour.Score()
+				tour.mutate(rng, op, 1)
+				checkScore(t, tour, fmt.Sprintf("asym=%t %s mutation %d", asym, op, i))
+			}
+		}
+		tour.Score()
+		tour.Shuffle(rng)
+		checkScore(t, tour, "shuffled tour")
+	}
+}
+
+func TestMutationRate(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	gt := randomGenotype(rng, 20, false, false)
+	for _, op := range []string{InversionMutation, InsertionMutation, ScrambleMutation, RandomMutation} {
+		changed := 0
+		for range 100 {
+			tour := gt.RandomTour(rng)
+			path := slices.Clone(tour.path)
+			if tour.mutate(rng, op, 0); !slices.Equal(tour.path, path) {
+				t.Fatalf("%s: rate 0 changed the tour", op)
+			}
+			if tour.mutate(rng, op, 1); !slices.Equal(tour.path, path) {
+				changed++
+			}
+			if err := tour.Validate(gt); err != nil {
+				t.Fatalf("%s: %s", op, err)
+			}
+		}
+		// Scrambling a short segment can leave it as it was
+		if changed < 90 || op != ScrambleMutation && op != RandomMutation && changed < 100 {
+			t.Errorf("%s: rate 1 changed %d of 100 tours", op, changed)
+		}
+	}
+}
+
+func TestCrossoverRate(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	gt := randomGenotype(rng, 20, false, false)
+	cfg := DefaultConfig()
+	for range 100 {
+		t1, t2 := gt.RandomTour(rng), gt.RandomTour(rng)
+		if cfg.CrossoverRate = 0; len(t1.Crossover(rng, t2, cfg)) > 0 {
+			t.Fatal("rate 0 bred children")
+		}
+		if cfg.CrossoverRate = 1; len(t1.Crossover(rng, t2, cfg)) == 0 {
+			t.Fatal("rate 1 bred no children")
+		}
+	}
+}
+
+func TestAdaptiveRate(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MutationRate, cfg.MinMutationRate, cfg.MaxMutationRate = 0.1, 0.05, 0.2
+	rate := newAdaptiveRate(cfg)
+	last := cfg.MutationRate
+	for range 20 {
+		got := rate.update(false)
+		if got < last || got > cfg.MaxMutationRate {
+			t.Fatalf("stagnating rate went from %f to %f", last, got)
+		}
+		last = got
+	}
+	if last != cfg.MaxMutationRate {
+		t.Errorf("stagnating rate %f, want the maximum %f", last, cfg.MaxMutationRate)
+	}
+	for range 20 {
+		got := rate.update(true)
+		if got > last || got < cfg.MinMutationRate {
+			t.Fatalf("improving rate went from %f to %f", last, got)
+		}
+		last = got
+	}
+	if last != cfg.MinMutationRate {
+		t.Errorf("improving rate %f, want the minimum %f", last, cfg.MinMutationRate)
+	}
+	cfg.MutationRate = 1
+	if got := newAdaptiveRate(cfg).rate; got != cfg.MaxMutationRate {
+		t.Errorf("initial rate %f, want it clamped to %f", got, cfg.MaxMutationRate)
+	}
+}
@@ -0,0 +1,88 @@
+package tsp
+
+import (
+	"cmp"
+	"slices"
+)
+
+// NeighborLists returns the indices of the k nearest cities to each city of
+// the search space (or all the others, if there are fewer), from nearest to
+// farthest by the distance matrix.
+func (gt Genotype) NeighborLists(k int) [][]int {
+	n := len(gt.genes)
+	lists := make([][]int, n)
+	for i := range n {
+		others := make([]int, 0, n-1)
+		for j := range n {
+			if j != i {
+				others = append(others, j)
+			}
+		}
+		slices.SortStableFunc(others, func(a, b int) int {
+			return cmp.Compare(gt.matrix[i][a], gt.matrix[i][b])
+		})
+		lists[i] = others[:min(k, len(others))]
+	}
+	return lists
+}
+
+// SetNeighbors sets the number of nearest neighbors of each city considered by
+// the 2-opt moves of local search (all cities when zero). It must be called
+// after loading cities and setting the metric.
+func (gt *Genotype) SetNeighbors(k int) {
+	gt.neighbors = nil
+	if k > 0 {
+		gt.neighbors = gt.NeighborLists(k)
+	}
+}
+
+// TwoOptNeighbors is the 2-opt local search restricted to neighbor lists (see
+// NeighborLists): it only tries to replace the edge from each city to its
+// successor with an edge to one of the city's nearer neighbors. An open tour
+// may also reverse its prefix up to a city, if its first city is nearer the
+// successor. It repeats until no such move shortens the tour.
+func (t *Tour) TwoOptNeighbors(neighbors [][]int) {
+	dist := t.metric()
+	n := len(t.path)
+	position := make([]int, len(t.cities))
+	for i, city := range t.path {
+		position[city] = i
+	}
+	// Reverse the cities between the edges after indices lo and hi, if that
+	// shortens the tour
+	improve := func(lo, hi int) bool {
+		delta := t.inversionDelta(lo+1, hi)
+		if delta >= -minGain {
+			return false
+		}
+		slices.Reverse(t.path[lo+1 : hi+1])
+		for k := lo + 1; k <= hi; k++ {
+			position[t.path[k]] = k
+		}
+		t.score += delta
+		return true
+	}
+	for improved := true; improved; {
+		improved = false
+		for i := range n {
+			a, b := t.path[i], t.path[(i+1)%n]
+			for _, c := range neighbors[a] {
+				if dist(a, c) >= dist(a, b) {
+					break
+				}
+				lo, hi := min(i, position[c]), max(i, position[c])
+				if hi-lo < 2 || lo == 0 && hi == n-1 {
+					continue
+				}
+				if improve(lo, hi) {
+					improved = true
+					break
+				}
+			}
+			a, b = t.path[i], t.path[(i+1)%n]
+			if t.open && 0 < i && i < n-1 && dist(t.path[0], b) < dist(a, b) && improve(-1, i) {
+				improved = true
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package tsp
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestNeighborLists(t *testing.T) {
+	gt := randomGenotype(rand.New(rand.NewSource(1)), 10, false, false)
+	for _, k := range []int{1, 3, 9, 20} {
+		lists := gt.NeighborLists(k)
+		for i, list := range lists {
+			if len(list) != min(k, 9) || slices.Contains(list, i) {
+				t.Fatalf("k=%d: neighbors of %d are %v", k, i, list)
+			}
+			for j := 1; j < len(list); j++ {
+				if gt.matrix[i][list[j]] < gt.matrix[i][list[j-1]] {
+					t.Fatalf("k=%d: neighbors of %d are not nearest first: %v", k, i, list)
+				}
+			}
+			// No city left out is nearer than the farthest listed
+			for other := range 10 {
+				if other != i && !slices.Contains(list, other) && gt.matrix[i][other] < gt.matrix[i][list[len(list)-1]] {
+					t.Fatalf("k=%d: %d is nearer %d than its neighbors %v", k, other, i, list)
+				}
+			}
+		}
+	}
+}
+
+func TestTwoOptNeighbors(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, open := range []bool{false, true} {
+		gt := randomGenotype(rng, 100, open, false)
+		gt.SetNeighbors(8)
+		for trial := range 10 {
+			tour := gt.RandomTour(rng)
+			before := tour.Score()
+			tour.TwoOptNeighbors(gt.neighbors)
+			name := fmt.Sprintf("open=%t trial=%d", open, trial)
+			checkScore(t, tour, name)
+			if err := tour.Validate(gt); err != nil {
+				t.Fatalf("%s: %s", name, err)
+			}
+			if tour.Score() > before/2 {
+				t.Errorf("%s: optimized a tour from %f to only %f", name, before, tour.Score())
+			}
+		}
+	}
+}
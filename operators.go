@@ -0,0 +1,78 @@
+package tsp
+
+import "math/rand"
+
+// Selector is a selection operator: it picks two parents from a population.
+type Selector interface {
+	Select(rng *rand.Rand, p Population) (Tour, Tour)
+}
+
+// Crossoverer is a crossover operator: it breeds children from two parents.
+type Crossoverer interface {
+	Crossover(rng *rand.Rand, t1, t2 Tour) []Tour
+}
+
+// Mutator is a mutation operator: with the given probability, it changes a
+// tour, replacing it with a tour of the same cities. A mutator outside this
+// package can change a copy of the tour's Path and build the new tour with
+// Genotype.TourOf, from a genotype with the same settings as the one solved
+// (such as SetOpen).
+type Mutator interface {
+	Mutate(rng *rand.Rand, t *Tour, rate float64)
+}
+
+// SelectorFunc adapts a function to a Selector.
+type SelectorFunc func(rng *rand.Rand, p Population) (Tour, Tour)
+
+// Select calls the function.
+func (f SelectorFunc) Select(rng *rand.Rand, p Population) (Tour, Tour) {
+	return f(rng, p)
+}
+
+// CrossoverFunc adapts a function to a Crossoverer.
+type CrossoverFunc func(rng *rand.Rand, t1, t2 Tour) []Tour
+
+// Crossover calls the function.
+func (f CrossoverFunc) Crossover(rng *rand.Rand, t1, t2 Tour) []Tour {
+	return f(rng, t1, t2)
+}
+
+// MutatorFunc adapts a function to a Mutator.
+type MutatorFunc func(rng *rand.Rand, t *Tour, rate float64)
+
+// Mutate calls the function.
+func (f MutatorFunc) Mutate(rng *rand.Rand, t *Tour, rate float64) {
+	f(rng, t, rate)
+}
+
+// Return the configured selector, or the selection operator named in the
+// config.
+func (c Config) selector() Selector {
+	if c.Selector != nil {
+		return c.Selector
+	}
+	return SelectorFunc(func(rng *rand.Rand, p Population) (Tour, Tour) {
+		return p.selectParents(rng, c)
+	})
+}
+
+// Return the configured crossoverer, or the crossover operator named in the
+// config.
+func (c Config) crossoverer() Crossoverer {
+	if c.Crossoverer != nil {
+		return c.Crossoverer
+	}
+	return CrossoverFunc(func(rng *rand.Rand, t1, t2 Tour) []Tour {
+		return t1.crossover(rng, t2, c.Crossover)
+	})
+}
+
+// Return the configured mutator, or the mutation operator named in the config.
+func (c Config) mutator() Mutator {
+	if c.Mutator != nil {
+		return c.Mutator
+	}
+	return MutatorFunc(func(rng *rand.Rand, t *Tour, rate float64) {
+		t.mutate(rng, c.Mutation, rate)
+	})
+}
@@ -0,0 +1,54 @@
+package tsp
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestMutator(t *testing.T) {
+	gt := randomGenotype(rand.New(rand.NewSource(1)), 20, true, false)
+	cfg := DefaultConfig()
+	cfg.Seed, cfg.Open, cfg.MaxGenerations, cfg.CrossoverRate = 1, true, 10, 1
+	calls := 0
+	cfg.Mutator = MutatorFunc(func(rng *rand.Rand, tour *Tour, rate float64) {
+		calls++
+		mutated, err := gt.TourOf(tour.Path())
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		*tour = mutated
+	})
+	result, err := SolveDeterministic(gt, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls == 0 {
+		t.Error("mutator not called")
+	}
+	if err := result.Best.Validate(gt); err != nil {
+		t.Error(err)
+	}
+	checkScore(t, result.Best, "best tour")
+}
+
+func TestTourOf(t *testing.T) {
+	gt := randomGenotype(rand.New(rand.NewSource(1)), 5, false, false)
+	tour := gt.RandomTour(rand.New(rand.NewSource(1)))
+	path := tour.Path()
+	path[0], path[1] = path[1], path[0]
+	if slices.Equal(path, tour.path) {
+		t.Fatal("changing a path changed the tour")
+	}
+	swapped, err := gt.TourOf(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkScore(t, swapped, "swapped tour")
+	for _, path := range [][]int{{0, 1, 2, 3}, {0, 1, 2, 3, 3}, {0, 1, 2, 3, 5}} {
+		if _, err := gt.TourOf(path); err == nil {
+			t.Errorf("path %v accepted", path)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package tsp
+
+import "time"
+
+// Option sets a parameter of a config (see NewConfig).
+type Option func(*Config)
+
+// NewConfig returns the default config with the options applied, in order. It
+// returns an error if the resulting config is invalid.
+func NewConfig(opts ...Option) (Config, error) {
+	cfg := DefaultConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg, cfg.Validate()
+}
+
+// SolveWith is like Solve, but configured by options over the defaults.
+func SolveWith(gt Genotype, opts ...Option) (Result, error) {
+	cfg, err := NewConfig(opts...)
+	if err != nil {
+		return Result{}, err
+	}
+	return Solve(gt, cfg)
+}
+
+// WithWorkers sets the number of GA islands.
+func WithWorkers(n int) Option {
+	return func(c *Config) { c.Workers = n }
+}
+
+// WithPopulation sets the number of tours in each population.
+func WithPopulation(n int) Option {
+	return func(c *Config) { c.Population = n }
+}
+
+// WithOffspring sets the number of children bred per generation.
+func WithOffspring(n int) Option {
+	return func(c *Config) { c.Offspring = n }
+}
+
+// WithDuration sets the time limit of the search.
+func WithDuration(d time.Duration) Option {
+	return func(c *Config) { c.Duration = d }
+}
+
+// WithSeed sets the random seed.
+func WithSeed(seed int64) Option {
+	return func(c *Config) { c.Seed = seed }
+}
+
+// WithMaxGenerations sets the maximum number of generations of each island.
+func WithMaxGenerations(n int) Option {
+	return func(c *Config) { c.MaxGenerations = n }
+}
+
+// WithStagnation sets the number of generations without improvement after
+// which an island stops.
+func WithStagnation(n int) Option {
+	return func(c *Config) { c.Stagnation = n }
+}
+
+// WithSelection sets the selection operator.
+func WithSelection(name string) Option {
+	return func(c *Config) { c.Selection = name }
+}
+
+// WithTournament selects parents by tournaments of the given size.
+func WithTournament(size int) Option {
+	return func(c *Config) { c.Selection, c.TournamentSize = TournamentSelection, size }
+}
+
+// WithCrossover sets the crossover operator and rate.
+func WithCrossover(name string, rate float64) Option {
+	return func(c *Config) { c.Crossover, c.CrossoverRate = name, rate }
+}
+
+// WithCrossoverRate sets the probability that selected parents breed.
+func WithCrossoverRate(rate float64) Option {
+	return func(c *Config) { c.CrossoverRate = rate }
+}
+
+// WithMutation sets the mutation operator.
+func WithMutation(name string) Option {
+	return func(c *Config) { c.Mutation = name }
+}
+
+// WithMutationRate sets the probability that a child is mutated.
+func WithMutationRate(rate float64) Option {
+	return func(c *Config) { c.MutationRate = rate }
+}
+
+// WithLocalSearch optimizes every child with 2-opt and Or-opt.
+func WithLocalSearch() Option {
+	return func(c *Config) { c.LocalSearch = true }
+}
+
+// WithElitism sets the number of best tours protected from replacement.
+func WithElitism(n int) Option {
+	return func(c *Config) { c.Elitism = n }
+}
@@ -0,0 +1,43 @@
+package tsp
+
+import (
+	"math"
+	"sync"
+)
+
+// BestParallel returns the same tour as Best, but scores the population with
+// a pool of workers, each over its own contiguous chunk of tours. It pays off
+// for large populations of dirty tours.
+func (p Population) BestParallel(workers int) Tour {
+	n := len(p.solutions)
+	workers = max(1, min(workers, n))
+	size := (n + workers - 1) / workers
+	bests := make([]int, workers)
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			best, bestScore := -1, math.MaxFloat64
+			for i := w * size; i < min(n, (w+1)*size); i++ {
+				if score := p.solutions[i].Score(); score < bestScore {
+					best, bestScore = i, score
+				}
+			}
+			bests[w] = best
+		}()
+	}
+	wg.Wait()
+
+	// Reduce in chunk order, so ties go to the first tour as in Best
+	best := -1
+	for _, i := range bests {
+		if i >= 0 && (best < 0 || p.solutions[i].Score() < p.solutions[best].Score()) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return Tour{}
+	}
+	return p.solutions[best]
+}
@@ -0,0 +1,62 @@
+package tsp
+
+import (
+	"math/rand"
+	"runtime"
+	"slices"
+	"testing"
+)
+
+// Return a population of random tours, with copies of some so that scores
+// tie.
+func randomPopulation(rng *rand.Rand, gt Genotype, size int) Population {
+	p := Population{}
+	for i := range size {
+		if i%10 == 9 {
+			p.solutions = append(p.solutions, p.solutions[rng.Intn(i)].Clone())
+		} else {
+			p.solutions = append(p.solutions, gt.RandomTour(rng))
+		}
+	}
+	return p
+}
+
+func TestBestParallel(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	gt := randomGenotype(rng, 20, false, false)
+	for _, size := range []int{0, 1, 7, 100} {
+		p := randomPopulation(rng, gt, size)
+		want := p.Best()
+		for _, workers := range []int{0, 1, 2, 3, 8, 200} {
+			for i := range p.solutions {
+				p.solutions[i].dirty = true
+			}
+			if got := p.BestParallel(workers); !slices.Equal(got.path, want.path) {
+				t.Errorf("size %d, %d workers: best %v, want %v", size, workers, got.path, want.path)
+			}
+		}
+	}
+}
+
+func BenchmarkBest(b *testing.B) {
+	gt := loadCapitals(b)
+	p := randomPopulation(rand.New(rand.NewSource(1)), gt, 1000)
+	for _, bench := range []struct {
+		name string
+		best func() Tour
+	}{
+		{"serial", p.Best},
+		{"parallel", func() Tour { return p.BestParallel(runtime.NumCPU()) }},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.ResetTimer()
+			for range b.N {
+				for i := range p.solutions {
+					p.solutions[i].dirty = true
+				}
+				bench.best()
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package tsp
+
+import "math/rand"
+
+// Names of the replacement strategies.
+const (
+	GenerationalReplacement = "generational"
+	SteadyStateReplacement  = "steady-state"
+	CrowdingReplacement     = "crowding"
+)
+
+// Return the index of the tour a child replaces under the configured strategy,
+// or -1 if the child is discarded. Elite tours are never replaced.
+func (p Population) replaced(rng *rand.Rand, cfg Config, child Tour, elite map[int]bool) int {
+	i := rng.Intn(len(p.solutions))
+	switch cfg.Replacement {
+	case SteadyStateReplacement:
+		i = p.worst()
+	case CrowdingReplacement:
+		i = p.mostSimilar(child)
+	}
+	if elite[i] || child.Score() > p.solutions[i].Score() {
+		return -1
+	}
+	return i
+}
+
+// Return the index of the tour with the highest score.
+func (p Population) worst() int {
+	worst := 0
+	for i := range p.solutions {
+		if p.solutions[i].Score() > p.solutions[worst].Score() {
+			worst = i
+		}
+	}
+	return worst
+}
+
+// Return the index of the tour that shares the most edges with a tour.
+func (p Population) mostSimilar(tour Tour) int {
+	next, prev := tour.adjacency()
+	similar, mostShared := 0, -1
+	for i, other := range p.solutions {
+		if shared := other.sharedEdges(next, prev); shared > mostShared {
+			similar, mostShared = i, shared
+		}
+	}
+	return similar
+}
@@ -0,0 +1,54 @@
+package tsp
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestReplacement(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	gt := randomGenotype(rng, 20, false, false)
+	p := Population{}
+	for range 10 {
+		p.solutions = append(p.solutions, gt.RandomTour(rng))
+	}
+	ranked := p.ranked()
+	best, worst := p.solutions[ranked[0]], p.solutions[ranked[9]]
+	cfg := DefaultConfig()
+
+	cfg.Replacement = SteadyStateReplacement
+	if i := p.replaced(rng, cfg, best.Clone(), nil); i != ranked[9] {
+		t.Errorf("steady-state replacement of tour %d, want the worst tour %d", i, ranked[9])
+	}
+	if i := p.replaced(rng, cfg, best.Clone(), map[int]bool{ranked[9]: true}); i >= 0 {
+		t.Errorf("steady-state replacement of elite tour %d", i)
+	}
+	worse := worst.Clone()
+	worse.score, worse.dirty = worst.Score()+1, false
+	if i := p.replaced(rng, cfg, worse, nil); i >= 0 {
+		t.Errorf("steady-state replacement of tour %d by a worse tour", i)
+	}
+
+	// A rotated copy of a tour shares all its edges, so crowding replaces it
+	cfg.Replacement = CrowdingReplacement
+	for _, i := range ranked[1:] {
+		similar := p.solutions[i].Clone()
+		similar.rotate(3)
+		if got := p.replaced(rng, cfg, similar, nil); got != i {
+			t.Errorf("crowding replacement of tour %d by a copy of tour %d", got, i)
+		}
+	}
+	if i := p.replaced(rng, cfg, best.Clone(), map[int]bool{ranked[0]: true}); i >= 0 {
+		t.Errorf("crowding replacement of elite tour %d", i)
+	}
+
+	cfg.Replacement = GenerationalReplacement
+	for range 100 {
+		if i := p.replaced(rng, cfg, best.Clone(), map[int]bool{ranked[0]: true}); i == ranked[0] {
+			t.Fatal("generational replacement of the elite tour")
+		}
+		if i := p.replaced(rng, cfg, worse, nil); i >= 0 {
+			t.Fatalf("generational replacement of tour %d by a worse tour", i)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package tsp
+
+import "math/rand"
+
+// Restart re-seeds a collapsed population: the kept best tours survive and the
+// rest are replaced by random tours.
+func (p *Population) Restart(rng *rand.Rand, gt Genotype, kept int) {
+	for _, i := range p.ranked()[min(kept, len(p.solutions)):] {
+		p.solutions[i] = gt.RandomTour(rng)
+	}
+}
+
+// Determine whether an island should restart, given its generations without
+// improvement since the last restart and its diversity (which is only fresh
+// every diversityInterval generations).
+func (c Config) restartDue(generations, idle int, diversity float64) bool {
+	if c.RestartStagnation > 0 && idle >= c.RestartStagnation {
+		return true
+	}
+	return c.RestartDiversity > 0 && generations%diversityInterval == 0 && diversity < c.RestartDiversity
+}
+
+// Return the number of tours kept by a restart: the configured fraction of the
+// population, but at least the elite and the best tour.
+func (c Config) restartKept() int {
+	return max(1, c.Elitism, int(c.RestartKeep*float64(c.Population)))
+}
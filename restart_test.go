@@ -0,0 +1,56 @@
+package tsp
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestRestart(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	gt := randomGenotype(rng, 20, false, false)
+	p := Population{}
+	for range 20 {
+		p.solutions = append(p.solutions, gt.RandomTour(rng))
+	}
+	kept := map[int][]int{}
+	for _, i := range p.ranked()[:5] {
+		kept[i] = slices.Clone(p.solutions[i].path)
+	}
+	p.Restart(rng, gt, 5)
+	for i, path := range kept {
+		if !slices.Equal(p.solutions[i].path, path) {
+			t.Errorf("kept tour %d was replaced", i)
+		}
+	}
+	for i := range p.solutions {
+		if err := p.solutions[i].Validate(gt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg := DefaultConfig()
+	cfg.RestartStagnation, cfg.RestartDiversity = 5, 0.1
+	for _, test := range []struct {
+		generations, idle int
+		diversity         float64
+		want              bool
+	}{
+		{generations: 3, idle: 5, diversity: 1, want: true},
+		{generations: 3, idle: 4, diversity: 0.05, want: false}, // Diversity is stale
+		{generations: 10, idle: 4, diversity: 0.05, want: true},
+		{generations: 10, idle: 4, diversity: 0.5, want: false},
+	} {
+		if got := cfg.restartDue(test.generations, test.idle, test.diversity); got != test.want {
+			t.Errorf("%+v: restart %t", test, got)
+		}
+	}
+	cfg.Population, cfg.RestartKeep, cfg.Elitism = 100, 0.1, 20
+	if got := cfg.restartKept(); got != 20 {
+		t.Errorf("restart keeps %d tours, want the elite of 20", got)
+	}
+	cfg.RestartKeep, cfg.Elitism = 0, 0
+	if got := cfg.restartKept(); got != 1 {
+		t.Errorf("restart keeps %d tours, want the best", got)
+	}
+}
@@ -0,0 +1,133 @@
+package tsp
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// Names of the selection operators.
+const (
+	UniformSelection    = "uniform"
+	TournamentSelection = "tournament"
+	RouletteSelection   = "roulette"
+	SUSSelection        = "sus"
+	BoltzmannSelection  = "boltzmann"
+)
+
+// SelectTournament is the tournament selection operator: each parent is the
+// best of k tours sampled at random.
+func (p Population) SelectTournament(rng *rand.Rand, k int) (Tour, Tour) {
+	return p.tournament(rng, k), p.tournament(rng, k)
+}
+
+// Return the best of k tours sampled at random from the population.
+func (p Population) tournament(rng *rand.Rand, k int) Tour {
+	best := rng.Intn(len(p.solutions))
+	for range k - 1 {
+		i := rng.Intn(len(p.solutions))
+		if p.solutions[i].Score() < p.solutions[best].Score() {
+			best = i
+		}
+	}
+	return p.solutions[best]
+}
+
+// SelectRoulette is the fitness proportionate selection operator. Since a lower
+// score is better, the fitness of a tour is how much shorter it is than the
+// worst tour. If every tour has the same score, selection is uniform.
+func (p Population) SelectRoulette(rng *rand.Rand) (Tour, Tour) {
+	wheel := p.rouletteWheel()
+	if wheel == nil {
+		return p.Select(rng)
+	}
+	return p.spin(rng, wheel), p.spin(rng, wheel)
+}
+
+// Return the cumulative fitness of the population, or nil if every tour has
+// the same score.
+func (p Population) rouletteWheel() []float64 {
+	worst := 0.0
+	for i := range p.solutions {
+		worst = max(worst, p.solutions[i].Score())
+	}
+	wheel := make([]float64, len(p.solutions))
+	total := 0.0
+	for i := range p.solutions {
+		total += worst - p.solutions[i].Score()
+		wheel[i] = total
+	}
+	if total == 0 {
+		return nil
+	}
+	return wheel
+}
+
+// Return the tour that a random spin of a roulette wheel lands on.
+func (p Population) spin(rng *rand.Rand, wheel []float64) Tour {
+	r := rng.Float64() * wheel[len(wheel)-1]
+	return p.solutions[sort.Search(len(wheel), func(i int) bool {
+		return wheel[i] > r
+	})]
+}
+
+// SelectSUS is the stochastic universal sampling operator. It selects n tours
+// with a single spin of the roulette wheel (see SelectRoulette) and n evenly
+// spaced pointers, so each tour is selected about as often as its share of the
+// fitness predicts, with less noise than n separate spins.
+func (p Population) SelectSUS(rng *rand.Rand, n int) []Tour {
+	selected := make([]Tour, 0, n)
+	wheel := p.rouletteWheel()
+	if wheel == nil {
+		for range n {
+			selected = append(selected, p.solutions[rng.Intn(len(p.solutions))])
+		}
+		return selected
+	}
+	step := wheel[len(wheel)-1] / float64(n)
+	pointer, i := rng.Float64()*step, 0
+	for range n {
+		for i < len(wheel)-1 && wheel[i] <= pointer {
+			i++
+		}
+		selected = append(selected, p.solutions[i])
+		pointer += step
+	}
+	return selected
+}
+
+// SelectBoltzmann is the Boltzmann selection operator: the probability that a
+// tour is selected is proportional to exp(-score/temperature). Selection is
+// near uniform at high temperatures, and near greedy at low ones.
+func (p Population) SelectBoltzmann(rng *rand.Rand, temperature float64) (Tour, Tour) {
+	// Scores are measured from the best, so the weights cannot all underflow
+	tour := p.Best()
+	best := tour.Score()
+	wheel := make([]float64, len(p.solutions))
+	total := 0.0
+	for i := range p.solutions {
+		if delta := p.solutions[i].Score() - best; delta > 0 {
+			total += math.Exp(-delta / temperature)
+		} else {
+			total++
+		}
+		wheel[i] = total
+	}
+	return p.spin(rng, wheel), p.spin(rng, wheel)
+}
+
+// Select two parents with the selection operator named in the config.
+func (p Population) selectParents(rng *rand.Rand, cfg Config) (Tour, Tour) {
+	switch cfg.Selection {
+	case TournamentSelection:
+		return p.SelectTournament(rng, cfg.TournamentSize)
+	case RouletteSelection:
+		return p.SelectRoulette(rng)
+	case BoltzmannSelection:
+		return p.SelectBoltzmann(rng, cfg.BoltzmannTemperature)
+	case SUSSelection:
+		parents := p.SelectSUS(rng, 2)
+		return parents[0], parents[1]
+	}
+	return p.Select(rng)
+}
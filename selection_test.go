@@ -0,0 +1,63 @@
+package tsp
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSelectionPressure(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	gt := randomGenotype(rng, 20, false, false)
+	p := randomPopulation(rng, gt, 20)
+	tour := p.Best()
+	best, worst, mean := tour.Score(), 0.0, 0.0
+	for i := range p.solutions {
+		worst = max(worst, p.solutions[i].Score())
+		mean += p.solutions[i].Score() / 20
+	}
+	spread := worst - best
+	// Return the mean score of the parents picked by a selection operator
+	selected := func(pick func() []Tour) (total float64) {
+		for range 5000 {
+			for _, tour := range pick() {
+				total += tour.Score() / 10000
+			}
+		}
+		return
+	}
+	pair := func(select2 func() (Tour, Tour)) func() []Tour {
+		return func() []Tour {
+			t1, t2 := select2()
+			return []Tour{t1, t2}
+		}
+	}
+	uniform := selected(pair(func() (Tour, Tour) { return p.Select(rng) }))
+	if uniform < mean-spread/10 || uniform > mean+spread/10 {
+		t.Errorf("uniform selection: mean score %f, population mean %f", uniform, mean)
+	}
+	for _, test := range []struct {
+		name string
+		pick func() []Tour
+	}{
+		{"tournament", pair(func() (Tour, Tour) { return p.SelectTournament(rng, 3) })},
+		{"roulette", pair(func() (Tour, Tour) { return p.SelectRoulette(rng) })},
+		{"sus", func() []Tour { return p.SelectSUS(rng, 2) }},
+		{"boltzmann", pair(func() (Tour, Tour) { return p.SelectBoltzmann(rng, spread/10) })},
+	} {
+		if got := selected(test.pick); got > uniform-spread/10 {
+			t.Errorf("%s selection: mean score %f, uniform selection %f", test.name, got, uniform)
+		}
+	}
+	if got := selected(pair(func() (Tour, Tour) { return p.SelectBoltzmann(rng, spread*1e6) })); got < mean-spread/10 || got > mean+spread/10 {
+		t.Errorf("hot Boltzmann selection: mean score %f, population mean %f", got, mean)
+	}
+	if got := selected(pair(func() (Tour, Tour) { return p.SelectBoltzmann(rng, spread*1e-6) })); math.Abs(got-best) > 1e-6 {
+		t.Errorf("cold Boltzmann selection: mean score %f, best %f", got, best)
+	}
+	for range 1000 {
+		if t1, t2 := p.SelectRoulette(rng); t1.Score() == worst || t2.Score() == worst {
+			t.Fatal("roulette selection picked the worst tour")
+		}
+	}
+}
@@ -0,0 +1,461 @@
+package tsp
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"math/rand"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Stats describes the progress and population of a GA island.
+type Stats struct {
+	Island     int           // Index of the island
+	Generation int           // Generations evolved by the island
+	Stagnant   int           // Generations since the best score improved
+	Elapsed    time.Duration // Time since the solver (or island) started
+	Score      float64       // Score of the best tour
+	Mean       float64       // Mean score of the population
+	Worst      float64       // Score of the worst tour
+	Diversity  float64       // Diversity of the population edges (see Population.Diversity)
+}
+
+// Reasons a solver stopped.
+const (
+	StopDuration       = "duration"        // The time limit passed
+	StopStagnation     = "stagnation"      // The best score stopped improving
+	StopMaxGenerations = "max generations" // The generation limit was reached
+	StopTarget         = "target"          // The best tour reached the target gap
+	StopCanceled       = "canceled"        // The context was canceled
+	StopTrivial        = "trivial"         // There were two or fewer cities
+	StopExact          = "exact"           // An exact solver found an optimal tour
+	StopTerminator     = "terminator"      // The configured Terminator stopped it
+)
+
+// Result is the outcome of a solver run.
+type Result struct {
+	Best        Tour
+	Score       float64       // Score of the best tour
+	Generations int           // Generations evolved by the longest running island
+	Elapsed     time.Duration // Time the run took
+	StopReason  string        // Why the run stopped (one of the Stop constants)
+}
+
+// Report is the best tour and statistics of a GA island after a generation.
+type Report struct {
+	Best  Tour
+	Stats Stats
+	Top   []Tour // The configured number of best distinct tours, if any
+
+	snapshot *Snapshot // The population of the island, when a checkpoint is due
+}
+
+// Solve runs competing GA go-routines (islands) to find a "good enough" tour
+// through the cities of a genotype, until the configured termination conditions
+// are met. It returns the best tour found by any island, with metadata about
+// the run. Each time a new best tour is found, the optional OnImprovement
+// callback is invoked from the calling go-routine. The optional OnGeneration
+// callback is likewise invoked with the statistics of every generation of every
+// island. Islands may periodically migrate their best tours to each other. With
+// the exact, sa or tabu Solver, Solve runs that solver instead.
+func Solve(gt Genotype, cfg Config) (Result, error) {
+	return SolveContext(context.Background(), gt, cfg)
+}
+
+// SolveContext is like Solve, but also stops the islands when the context is
+// done, returning the best tour found so far.
+func SolveContext(parent context.Context, gt Genotype, cfg Config) (Result, error) {
+	if err := gt.prepare(cfg); err != nil {
+		return Result{}, err
+	}
+	if len(gt.genes) <= 2 {
+		return trivial(gt, cfg), nil
+	}
+	if cfg.Seed == 0 {
+		cfg.Seed = time.Now().UnixNano()
+	}
+
+	// Terminates TSP go-routines after the time limit
+	ctx, cancel := context.WithTimeout(parent, cfg.Duration)
+	defer cancel()
+	if result, ok, err := single(ctx, gt, cfg); ok {
+		return result, err
+	}
+
+	// Start our GA routines, each with its own random source
+	c := newCollector(cfg)
+	var wg sync.WaitGroup
+	reports := make(chan Report)
+	islands := connect(cfg.Workers, cfg.MigrationTopology)
+	generations, reasons := make([]int, len(islands)), make([]string, len(islands))
+	for i := range islands {
+		rng := rand.New(rand.NewSource(cfg.Seed + int64(i)))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			generations[i], reasons[i] = island(ctx, rng, gt, cfg, sender(ctx, reports), islands[i])
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(reports)
+	}()
+
+	// Collect solutions and keep the best found
+	for report := range reports {
+		c.collect(report)
+		if cfg.targetReached(c.bestScore) {
+			cancel()
+		}
+	}
+	c.finish()
+
+	// Islands stopped by the collector or the contexts report them as canceled
+	reason := reasons[0]
+	switch {
+	case cfg.targetReached(c.bestScore):
+		reason = StopTarget
+	case parent.Err() != nil:
+		reason = StopCanceled
+	case ctx.Err() != nil:
+		reason = StopDuration
+	}
+	return c.result(slices.Max(generations), reason), nil
+}
+
+// SolveCities is like Solve, for a genotype of cities in memory (see
+// NewGenotype).
+func SolveCities(cities []City, cfg Config) (Result, error) {
+	gt := Genotype{}
+	gt.SetPlanar(cfg.Planar)
+	if err := gt.InitCities(cities); err != nil {
+		return Result{}, err
+	}
+	return Solve(gt, cfg)
+}
+
+// SolveDeterministic is like Solve, but runs a single island in the calling
+// go-routine with no time limit, so the same seed always gives the same tour.
+// The run must be bounded by max generations or stagnation.
+func SolveDeterministic(gt Genotype, cfg Config) (Result, error) {
+	return SolveDeterministicContext(context.Background(), gt, cfg)
+}
+
+// SolveDeterministicContext is like SolveDeterministic, but also stops when the
+// context is done, returning the best tour found so far.
+func SolveDeterministicContext(ctx context.Context, gt Genotype, cfg Config) (Result, error) {
+	if err := gt.prepare(cfg); err != nil {
+		return Result{}, err
+	}
+	if len(gt.genes) <= 2 {
+		return trivial(gt, cfg), nil
+	}
+	if result, ok, err := single(ctx, gt, cfg); ok {
+		return result, err
+	}
+	if cfg.MaxGenerations == 0 && cfg.Stagnation == 0 {
+		return Result{}, errors.New("Deterministic solve needs max generations or stagnation")
+	}
+	c := newCollector(cfg)
+	generations, reason := island(ctx, rand.New(rand.NewSource(cfg.Seed)), gt, cfg, func(report Report) bool {
+		if ctx.Err() != nil {
+			return false
+		}
+		c.collect(report)
+		return true
+	}, migration{})
+	c.finish()
+	return c.result(generations, reason), nil
+}
+
+// Check that a config can solve a genotype, and apply the config to it.
+func (gt *Genotype) prepare(cfg Config) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if err := gt.checkCities(); err != nil {
+		return err
+	}
+	if err := gt.checkAnchors(cfg); err != nil {
+		return err
+	}
+	for _, s := range cfg.Resume {
+		for _, tour := range s.Population.solutions {
+			if err := tour.Validate(*gt); err != nil {
+				return err
+			}
+		}
+	}
+	gt.SetOpen(cfg.Open)
+	gt.SetNeighbors(cfg.Neighbors)
+	return nil
+}
+
+// Return the optimal tour through two or fewer cities (any tour).
+func trivial(gt Genotype, cfg Config) Result {
+	best := gt.RandomTour(rand.New(rand.NewSource(cfg.Seed)))
+	best.anchor(cfg.Start, cfg.End)
+	result := Result{Best: best, Score: best.Score(), StopReason: StopTrivial}
+	cfg.found(result)
+	return result
+}
+
+// Run the solver of a config if it finds a single tour (exact, sa or tabu)
+// rather than evolving GA islands, and report whether it did. The auto solver
+// runs the exact solver when it is due. The sa and tabu solvers also stop when
+// the context is done.
+func single(ctx context.Context, gt Genotype, cfg Config) (result Result, ok bool, err error) {
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	start := time.Now()
+	switch {
+	case cfg.Solver == ExactSolver || cfg.exactDue(len(gt.genes)):
+		best, err := HeldKarp(gt)
+		if err != nil {
+			return Result{}, true, err
+		}
+		result = Result{best, best.Score(), 0, time.Since(start), StopExact}
+	case cfg.Solver == AnnealingSolver:
+		result = SimulatedAnnealingContext(ctx, rng, gt, cfg.Annealing)
+	case cfg.Solver == TabuSolver:
+		result = TabuSearchContext(ctx, rng, gt, cfg.Tabu)
+	default:
+		return Result{}, false, nil
+	}
+	cfg.found(result)
+	return result, true, nil
+}
+
+// Pass the best tour of a single tour solver to the callbacks of a config.
+func (c Config) found(result Result) {
+	best := result.Best
+	if c.OnImprovement != nil {
+		c.OnImprovement(best, Stats{Elapsed: result.Elapsed, Score: result.Score, Mean: result.Score, Worst: result.Score})
+	}
+	if c.OnTop != nil && c.Top > 0 {
+		c.OnTop([]Tour{best})
+	}
+}
+
+// Determine whether the auto solver should solve a number of cities exactly.
+func (c Config) exactDue(cities int) bool {
+	return c.Solver == AutoSolver && cities <= c.ExactThreshold && c.Start == "" && c.End == ""
+}
+
+// Collects the reports of islands, keeping the best tour and invoking the
+// callbacks of a config.
+type collector struct {
+	cfg       Config
+	logger    *slog.Logger
+	start     time.Time
+	best      Tour
+	bestScore float64
+	top       []Tour
+	snapshots []Snapshot
+}
+
+// Create a collector for a run starting now.
+func newCollector(cfg Config) *collector {
+	return &collector{cfg: cfg, logger: cfg.logger(), start: time.Now(), bestScore: math.MaxFloat64}
+}
+
+// Collect the report of an island.
+func (c *collector) collect(report Report) {
+	report.Stats.Elapsed = time.Since(c.start)
+	if c.cfg.OnGeneration != nil {
+		c.cfg.OnGeneration(report.Stats)
+	}
+	if report.snapshot != nil {
+		c.checkpoint(report.Stats.Island, *report.snapshot)
+	}
+	if len(report.Top) > 0 {
+		c.top = distinctBest(append(c.top, report.Top...), c.cfg.Top)
+	}
+	if report.Stats.Score < c.bestScore {
+		c.best, c.bestScore = report.Best, report.Stats.Score
+		c.logger.Info("New best tour",
+			"score", c.bestScore, "generation", report.Stats.Generation, "elapsed", report.Stats.Elapsed)
+		if c.cfg.OnImprovement != nil {
+			c.cfg.OnImprovement(c.best, report.Stats)
+		}
+	}
+}
+
+// Keep the latest snapshot of an island, and pass those of every island that
+// has one to the OnCheckpoint callback.
+func (c *collector) checkpoint(island int, s Snapshot) {
+	if c.cfg.OnCheckpoint == nil {
+		return
+	}
+	if island >= len(c.snapshots) {
+		c.snapshots = append(c.snapshots, make([]Snapshot, island+1-len(c.snapshots))...)
+	}
+	c.snapshots[island] = s
+	var snapshots []Snapshot
+	for _, s := range c.snapshots {
+		if len(s.Population.solutions) > 0 {
+			snapshots = append(snapshots, s)
+		}
+	}
+	c.cfg.OnCheckpoint(snapshots)
+}
+
+// Return the result of a run that is done.
+func (c *collector) result(generations int, reason string) Result {
+	return Result{c.best, c.bestScore, generations, time.Since(c.start), reason}
+}
+
+// Pass the best distinct tours to the OnTop callback, once every island is done.
+func (c *collector) finish() {
+	if c.cfg.OnTop != nil && c.cfg.Top > 0 {
+		c.cfg.OnTop(c.top)
+	}
+}
+
+// Report whether a done channel of a context is closed, without blocking.
+func stopped(done <-chan struct{}) bool {
+	select {
+	case <-done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Return why a done context stopped a solver: its deadline passed, or it was
+// canceled.
+func canceled(ctx context.Context) string {
+	if ctx.Err() == context.DeadlineExceeded {
+		return StopDuration
+	}
+	return StopCanceled
+}
+
+// Return a function that sends reports on a channel, and reports false once
+// the context is done instead.
+func sender(ctx context.Context, reports chan<- Report) func(Report) bool {
+	return func(report Report) bool {
+		select {
+		case reports <- report:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+// GeneticTSP continually evolves a population until the context is done, the
+// best score has not improved for the configured number of generations, or the
+// configured maximum number of generations is reached. The best tour and the
+// population statistics of each generation are reported on a channel. It
+// returns the number of generations evolved.
+func GeneticTSP(ctx context.Context, rng *rand.Rand, gt Genotype, cfg Config, reports chan<- Report) (generations int) {
+	generations, _ = island(ctx, rng, gt, cfg, sender(ctx, reports), migration{})
+	return
+}
+
+// A condition that stops a GA island, with the reason it gives.
+type stopCondition struct {
+	Terminator
+	reason string
+}
+
+// Return the conditions that stop a GA island after a generation: the
+// generation and stagnation limits of the config, and its Terminator.
+func (c Config) stopConditions() (conditions []stopCondition) {
+	if c.MaxGenerations > 0 {
+		conditions = append(conditions, stopCondition{MaxGenerations(c.MaxGenerations), StopMaxGenerations})
+	}
+	if c.Stagnation > 0 {
+		conditions = append(conditions, stopCondition{Stagnation(c.Stagnation), StopStagnation})
+	}
+	if c.Terminator != nil {
+		conditions = append(conditions, stopCondition{c.Terminator, StopTerminator})
+	}
+	return
+}
+
+// Run GeneticTSP as an island that periodically exchanges its best tours with
+// its neighbors, and takes in the global best tour. Each generation is passed
+// to report, which returns false to stop the island. It returns the number of
+// generations evolved and why the island stopped.
+func island(ctx context.Context, rng *rand.Rand, gt Genotype, cfg Config, report func(Report) bool, m migration) (generations int, reason string) {
+	p := Population{}
+	p.Init(rng, gt, cfg.Population, cfg.HeuristicFraction)
+	if len(cfg.Resume) > 0 {
+		s := cfg.Resume[m.id%len(cfg.Resume)]
+		p.resume(gt, s)
+		generations = s.Generation
+	}
+	p.anchor(cfg)
+	logger := cfg.logger()
+	rate := newAdaptiveRate(cfg)
+	bestScore, stagnant, idle, diversity := math.MaxFloat64, 0, 0, 0.0
+	start := time.Now()
+	conditions := cfg.stopConditions()
+	for {
+		best, stats := p.Best(), p.scoreStats()
+		if generations%diversityInterval == 0 {
+			diversity = p.Diversity()
+		}
+		stats.Island, stats.Generation, stats.Diversity = m.id, generations, diversity
+		if score := stats.Score; score < bestScore {
+			bestScore, stagnant, idle = score, 0, 0
+			if m.global != nil {
+				m.global.Update(best)
+			}
+		} else {
+			stagnant, idle = stagnant+1, idle+1
+		}
+		stats.Stagnant, stats.Elapsed = stagnant, time.Since(start)
+		if cfg.AdaptiveMutation {
+			cfg.MutationRate = rate.update(stagnant == 0)
+		}
+		if generations > 0 {
+			cfg.BoltzmannTemperature *= cfg.BoltzmannCooling
+		}
+		if cfg.restartDue(generations, idle, diversity) {
+			p.Restart(rng, gt, cfg.restartKept())
+			p.anchor(cfg)
+			diversity, idle = p.Diversity(), 0
+			logger.Debug("Island restarted", "generations", generations, "diversity", diversity)
+		}
+		var top []Tour
+		if cfg.Top > 0 {
+			top = p.TopK(cfg.Top)
+		}
+		var snapshot *Snapshot
+		if cfg.CheckpointInterval > 0 && generations%cfg.CheckpointInterval == 0 && generations > 0 {
+			snapshot = &Snapshot{generations, p.clone()}
+		}
+		if !report(Report{best.Clone(), stats, top, snapshot}) {
+			reason = canceled(ctx)
+			break
+		}
+		if cfg.targetReached(bestScore) {
+			reason = StopTarget
+			break
+		}
+		for _, c := range conditions {
+			if c.Stop(stats) {
+				reason = c.reason
+				break
+			}
+		}
+		if reason != "" {
+			break
+		}
+		p.Evolve(rng, cfg)
+		generations++
+		if cfg.MigrationInterval > 0 && generations%cfg.MigrationInterval == 0 {
+			p.migrate(rng, m, cfg.Migrants)
+		}
+		if cfg.BroadcastInterval > 0 && generations%cfg.BroadcastInterval == 0 && m.global != nil {
+			p.injectBest(m.global, bestScore)
+		}
+	}
+	logger.Debug("Island stopped", "reason", reason, "generations", generations)
+	return
+}
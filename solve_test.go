@@ -0,0 +1,252 @@
+package tsp
+
+import (
+	"context"
+	"maps"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSolveSingleStops(t *testing.T) {
+	gt := randomGenotype(rand.New(rand.NewSource(1)), 300, false, false)
+	for _, solver := range []string{AnnealingSolver, TabuSolver} {
+		cfg := DefaultConfig()
+		cfg.Solver, cfg.Duration = solver, 50*time.Millisecond
+		cfg.Annealing.Iterations, cfg.Tabu.Iterations = 1<<40, 1<<40
+		start := time.Now()
+		result, err := Solve(gt, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second || result.StopReason != StopDuration {
+			t.Errorf("%s: stopped by %s after %s with a 50ms time limit", solver, result.StopReason, elapsed)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		cfg.Duration = time.Hour
+		if result, err = SolveContext(ctx, gt, cfg); err != nil || result.StopReason != StopCanceled {
+			t.Errorf("%s: stopped by %s (error %v) when canceled", solver, result.StopReason, err)
+		}
+		if err := result.Best.Validate(gt); err != nil {
+			t.Errorf("%s: %s", solver, err)
+		}
+	}
+}
+
+func TestSolveFewCities(t *testing.T) {
+	if _, err := Solve(Genotype{}, DefaultConfig()); err == nil || err.Error() != "No cities found" {
+		t.Errorf("no cities: error %v", err)
+	}
+	for _, n := range []int{1, 2} {
+		gt := randomGenotype(rand.New(rand.NewSource(1)), n, false, false)
+		result, err := Solve(gt, DefaultConfig())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.StopReason != StopTrivial || result.Best.Validate(gt) != nil || result.Score != result.Best.length() {
+			t.Errorf("%d cities: stopped by %s with tour %v scoring %f", n, result.StopReason, result.Best.path, result.Score)
+		}
+	}
+}
+
+func TestSolveDeterministicSeed(t *testing.T) {
+	gt := randomGenotype(rand.New(rand.NewSource(1)), 30, false, false)
+	cfg := DefaultConfig()
+	cfg.Seed, cfg.MaxGenerations, cfg.LocalSearch = 7, 50, true
+	first, err := SolveDeterministic(gt, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 3 {
+		again, err := SolveDeterministic(gt, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(again.Best.path, first.Best.path) || again.Score != first.Score || again.Generations != first.Generations {
+			t.Fatalf("seed %d gave tours %v and %v", cfg.Seed, first.Best.path, again.Best.path)
+		}
+	}
+	cfg.MaxGenerations = 0
+	if _, err := SolveDeterministic(gt, cfg); err == nil {
+		t.Error("unbounded deterministic solve accepted")
+	}
+}
+
+func TestSolveLimits(t *testing.T) {
+	gt := randomGenotype(rand.New(rand.NewSource(1)), 30, false, false)
+	for _, test := range []struct {
+		name   string
+		config func(*Config)
+		reason string
+	}{
+		{"max generations", func(cfg *Config) { cfg.MaxGenerations = 25 }, StopMaxGenerations},
+		{"stagnation", func(cfg *Config) { cfg.Stagnation = 5 }, StopStagnation},
+	} {
+		cfg := DefaultConfig()
+		cfg.Seed, cfg.Workers, cfg.Duration = 1, 3, time.Minute
+		test.config(&cfg)
+		generations := make(map[int]int)
+		cfg.OnGeneration = func(stats Stats) {
+			generations[stats.Island] = max(generations[stats.Island], stats.Generation)
+			if cfg.MaxGenerations > 0 && stats.Generation > cfg.MaxGenerations {
+				t.Errorf("%s: island %d reported generation %d", test.name, stats.Island, stats.Generation)
+			}
+			if cfg.Stagnation > 0 && stats.Stagnant > cfg.Stagnation {
+				t.Errorf("%s: island %d stagnated for %d generations", test.name, stats.Island, stats.Stagnant)
+			}
+		}
+		result, err := Solve(gt, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.StopReason != test.reason {
+			t.Errorf("%s: stopped by %s", test.name, result.StopReason)
+		}
+		if want := slices.Max(slices.Collect(maps.Values(generations))); result.Generations != want || len(generations) != cfg.Workers {
+			t.Errorf("%s: %d generations, islands reported %v", test.name, result.Generations, generations)
+		}
+		if cfg.MaxGenerations > 0 && result.Generations != cfg.MaxGenerations {
+			t.Errorf("%s: %d generations, want %d", test.name, result.Generations, cfg.MaxGenerations)
+		}
+	}
+}
+
+func TestSolveResult(t *testing.T) {
+	gt := randomGenotype(rand.New(rand.NewSource(1)), 30, false, false)
+	cfg := DefaultConfig()
+	cfg.Seed, cfg.MaxGenerations, cfg.Top = 1, 50, 5
+	improvements := []float64{}
+	cfg.OnImprovement = func(tour Tour, stats Stats) {
+		if n := len(improvements); n > 0 && stats.Score >= improvements[n-1] {
+			t.Errorf("improvement from %f to %f", improvements[n-1], stats.Score)
+		}
+		improvements = append(improvements, stats.Score)
+	}
+	var top []Tour
+	cfg.OnTop = func(tours []Tour) { top = tours }
+	result, err := Solve(gt, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := result.Best.Validate(gt); err != nil {
+		t.Fatal(err)
+	}
+	if result.Score != result.Best.Score() || result.Score != improvements[len(improvements)-1] || result.Elapsed <= 0 {
+		t.Errorf("result scores %f after %s, best tour %f, last improvement %f", result.Score, result.Elapsed, result.Best.Score(), improvements[len(improvements)-1])
+	}
+	if len(top) == 0 || len(top) > cfg.Top || top[0].Score() != result.Score {
+		t.Errorf("%d top tours, want up to %d starting with the best", len(top), cfg.Top)
+	}
+}
+
+func TestSolveTarget(t *testing.T) {
+	gt := randomGenotype(rand.New(rand.NewSource(1)), 10, false, false)
+	optimal, err := HeldKarp(gt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := DefaultConfig()
+	cfg.Seed, cfg.Duration, cfg.LocalSearch = 1, time.Minute, true
+	cfg.Optimum, cfg.Target = optimal.Score(), 5
+	result, err := Solve(gt, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gap := Gap(result.Score, cfg.Optimum); result.StopReason != StopTarget || gap > cfg.Target {
+		t.Errorf("stopped by %s at a gap of %f%%", result.StopReason, gap)
+	}
+	if got := Gap(110, 100); math.Abs(got-10) > 1e-9 {
+		t.Errorf("gap of 110 over 100 is %f%%, want 10%%", got)
+	}
+}
+
+func TestSolveAuto(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Solver, cfg.Seed, cfg.MaxGenerations = AutoSolver, 1, 10
+	for _, n := range []int{cfg.ExactThreshold, cfg.ExactThreshold + 1} {
+		gt := randomGenotype(rand.New(rand.NewSource(1)), n, false, false)
+		result, err := Solve(gt, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n <= cfg.ExactThreshold {
+			optimal, err := HeldKarp(gt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.StopReason != StopExact || math.Abs(result.Score-optimal.Score()) > 1e-9 {
+				t.Errorf("%d cities: stopped by %s scoring %f, optimum %f", n, result.StopReason, result.Score, optimal.Score())
+			}
+		} else if result.StopReason != StopMaxGenerations {
+			t.Errorf("%d cities: stopped by %s, want the GA", n, result.StopReason)
+		}
+	}
+}
+
+func TestSolveWith(t *testing.T) {
+	cfg, err := NewConfig(WithWorkers(3), WithPopulation(50), WithSeed(9), WithCrossover(OrderCrossover, 0.5), WithElitism(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Workers != 3 || cfg.Population != 50 || cfg.Seed != 9 || cfg.Crossover != OrderCrossover || cfg.CrossoverRate != 0.5 || cfg.Elitism != 2 {
+		t.Errorf("options give config %+v", cfg)
+	}
+	if _, err := NewConfig(WithPopulation(0)); err == nil {
+		t.Error("invalid option accepted")
+	}
+	gt := randomGenotype(rand.New(rand.NewSource(1)), 20, false, false)
+	result, err := SolveWith(gt, WithSeed(1), WithMaxGenerations(10))
+	if err != nil || result.StopReason != StopMaxGenerations || result.Best.Validate(gt) != nil {
+		t.Errorf("stopped by %s (error %v)", result.StopReason, err)
+	}
+}
+
+func TestSolveCities(t *testing.T) {
+	cities := []City{{Name: "A", Lat: 0, Lon: 0}, {Name: "B", Lat: 0, Lon: 3}, {Name: "C", Lat: 4, Lon: 3}, {Name: "D", Lat: 4, Lon: 0}}
+	gt, err := NewGenotype(cities)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gt.Geographic() || len(gt.Cities()) != len(cities) {
+		t.Errorf("new genotype of %d cities (geographic %t)", len(gt.Cities()), gt.Geographic())
+	}
+	if _, err := NewGenotype(append(cities, cities[0])); err == nil || err.Error() != "Duplicate cities: A" {
+		t.Errorf("duplicate city: error %v", err)
+	}
+	cfg := DefaultConfig()
+	cfg.Seed, cfg.MaxGenerations, cfg.Planar = 1, 20, true
+	result, err := SolveCities(cities, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(result.Score-14) > 1e-9 {
+		t.Errorf("planar rectangle tour scores %f, want 14", result.Score)
+	}
+	cities[0].Lat = 100
+	if _, err := SolveCities(cities, DefaultConfig()); err == nil {
+		t.Error("out of range latitude accepted")
+	}
+}
+
+func TestCheckChildren(t *testing.T) {
+	gt := randomGenotype(rand.New(rand.NewSource(1)), 10, false, false)
+	cfg := DefaultConfig()
+	cfg.CrossoverRate, cfg.CheckChildren = 1, true
+	cfg.Crossoverer = CrossoverFunc(func(rng *rand.Rand, t1, t2 Tour) []Tour {
+		child := t1.Clone()
+		child.path[0] = child.path[1]
+		return []Tour{child}
+	})
+	p := Population{}
+	p.Init(rand.New(rand.NewSource(1)), gt, cfg.Population, cfg.HeuristicFraction)
+	defer func() {
+		if recover() == nil {
+			t.Error("invalid child accepted")
+		}
+	}()
+	p.Evolve(rand.New(rand.NewSource(1)), cfg)
+}
@@ -0,0 +1,102 @@
+package tsp
+
+import "math"
+
+// Generations between computations of the diversity of an island, which costs
+// more than the score statistics.
+const diversityInterval = 10
+
+// Stats returns the best, mean and worst scores and the diversity of a
+// population.
+func (p Population) Stats() Stats {
+	stats := p.scoreStats()
+	stats.Diversity = p.Diversity()
+	return stats
+}
+
+// Return the best, mean and worst scores of a population in one pass.
+func (p Population) scoreStats() (stats Stats) {
+	if len(p.solutions) == 0 {
+		return
+	}
+	stats.Score, stats.Worst = math.MaxFloat64, 0
+	sum := 0.0
+	for i := range p.solutions {
+		score := p.solutions[i].Score()
+		stats.Score = min(stats.Score, score)
+		stats.Worst = max(stats.Worst, score)
+		sum += score
+	}
+	stats.Mean = sum / float64(len(p.solutions))
+	return
+}
+
+// Worst returns the tour with the longest path (highest score).
+func (p Population) Worst() Tour {
+	if len(p.solutions) == 0 {
+		return Tour{}
+	}
+	return p.solutions[p.worst()]
+}
+
+// AverageScore returns the mean score of a population.
+func (p Population) AverageScore() float64 {
+	return p.scoreStats().Mean
+}
+
+// StdDevScore returns the (population) standard deviation of the scores of a
+// population, computed in one pass with Welford's method.
+func (p Population) StdDevScore() float64 {
+	mean, m2 := 0.0, 0.0
+	for i := range p.solutions {
+		score := p.solutions[i].Score()
+		delta := score - mean
+		mean += delta / float64(i+1)
+		m2 += delta * (score - mean)
+	}
+	if len(p.solutions) == 0 {
+		return 0
+	}
+	return math.Sqrt(m2 / float64(len(p.solutions)))
+}
+
+// Diversity is the mean fraction of the edges of each tour that are not in the
+// best tour. It is 0 when every tour has the same edges (for example, if the
+// population has converged) and approaches 1 for unrelated random tours.
+func (p Population) Diversity() float64 {
+	best := p.Best()
+	n := len(best.path)
+	if n < 2 {
+		return 0
+	}
+	next, prev := best.adjacency()
+	differ := 0
+	for _, tour := range p.solutions {
+		differ += n - tour.sharedEdges(next, prev)
+	}
+	return float64(differ) / float64(n*len(p.solutions))
+}
+
+// Return the next and previous city of each city in a tour.
+func (t Tour) adjacency() (next, prev []int) {
+	n := len(t.path)
+	next, prev = make([]int, n), make([]int, n)
+	for i, city := range t.path {
+		next[city] = t.path[(i+1)%n]
+		prev[city] = t.path[(i-1+n)%n]
+	}
+	return
+}
+
+// Count the edges of a tour that are also edges of the tour with the given
+// neighbors (in either direction).
+func (t Tour) sharedEdges(next, prev []int) (shared int) {
+	n := len(t.path)
+	for i, city := range t.path {
+		neighbor := t.path[(i+1)%n]
+		if next[city] == neighbor || prev[city] == neighbor {
+			shared++
+		}
+	}
+	return
+}
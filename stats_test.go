@@ -0,0 +1,58 @@
+package tsp
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestPopulationStats(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	gt := randomGenotype(rng, 20, false, false)
+	p := randomPopulation(rng, gt, 30)
+	best, worst, sum := math.MaxFloat64, 0.0, 0.0
+	for i := range p.solutions {
+		score := p.solutions[i].Score()
+		best, worst, sum = min(best, score), max(worst, score), sum+score
+	}
+	mean, squares := sum/30, 0.0
+	for i := range p.solutions {
+		squares += math.Pow(p.solutions[i].Score()-mean, 2)
+	}
+	stats, tour := p.Stats(), p.Worst()
+	if stats.Score != best || stats.Worst != worst || tour.Score() != worst {
+		t.Errorf("best %f and worst %f, want %f and %f", stats.Score, tour.Score(), best, worst)
+	}
+	if math.Abs(stats.Mean-mean) > 1e-6 || math.Abs(p.AverageScore()-mean) > 1e-6 {
+		t.Errorf("mean %f, want %f", p.AverageScore(), mean)
+	}
+	if got, want := p.StdDevScore(), math.Sqrt(squares/30); math.Abs(got-want) > 1e-6 {
+		t.Errorf("standard deviation %f, want %f", got, want)
+	}
+	empty := Population{}
+	if empty.Stats() != (Stats{}) || empty.StdDevScore() != 0 || len(empty.Worst().path) != 0 {
+		t.Error("empty population has statistics")
+	}
+}
+
+func TestDiversity(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	gt := randomGenotype(rng, 50, false, false)
+	tour := gt.RandomTour(rng)
+	p := Population{}
+	for i := range 10 {
+		same := tour.Clone()
+		same.rotate(i)
+		if i%2 == 1 {
+			slices.Reverse(same.path)
+		}
+		p.solutions = append(p.solutions, same)
+	}
+	if got := p.Diversity(); got != 0 {
+		t.Errorf("rotations and reversals of a tour have diversity %f", got)
+	}
+	if got := randomPopulation(rng, gt, 100).Diversity(); got < 0.8 || got > 1 {
+		t.Errorf("random tours have diversity %f", got)
+	}
+}
@@ -0,0 +1,49 @@
+package tsp
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Margin around the cities of an SVG map, in pixels.
+const svgMargin = 20
+
+// WriteSVG writes a tour to a writer as an SVG map of the given size. Cities are
+// placed with an equirectangular projection scaled to their bounding box, and
+// drawn as labeled circles joined by a line for each edge of the tour.
+func (t Tour) WriteSVG(w io.Writer, width, height int) error {
+	cities := t.Cities()
+	minLon, minLat, maxLon, maxLat := bounds(cities)
+	// Keep the aspect ratio, and avoid dividing by zero for collinear cities
+	scale := math.Min(
+		float64(width-2*svgMargin)/math.Max(maxLon-minLon, 1e-9),
+		float64(height-2*svgMargin)/math.Max(maxLat-minLat, 1e-9),
+	)
+	project := func(c City) (x, y float64) {
+		return svgMargin + (c.Lon-minLon)*scale, float64(height) - svgMargin - (c.Lat-minLat)*scale
+	}
+
+	out := bufio.NewWriter(w)
+	fmt.Fprintf(out, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\">\n", width, height)
+	edges := len(cities)
+	if t.open || edges < 2 {
+		edges--
+	}
+	for i := 0; i < edges; i++ {
+		x1, y1 := project(cities[i])
+		x2, y2 := project(cities[(i+1)%len(cities)])
+		fmt.Fprintf(out, "<line x1=\"%.2f\" y1=\"%.2f\" x2=\"%.2f\" y2=\"%.2f\" stroke=\"steelblue\"/>\n", x1, y1, x2, y2)
+	}
+	for _, city := range cities {
+		x, y := project(city)
+		fmt.Fprintf(out, "<circle cx=\"%.2f\" cy=\"%.2f\" r=\"3\" fill=\"firebrick\"/>\n", x, y)
+		fmt.Fprintf(out, "<text x=\"%.2f\" y=\"%.2f\" font-size=\"10\">", x+4, y-4)
+		xml.EscapeText(out, []byte(city.Name))
+		fmt.Fprintln(out, "</text>")
+	}
+	fmt.Fprintln(out, "</svg>")
+	return out.Flush()
+}
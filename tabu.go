@@ -0,0 +1,85 @@
+package tsp
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"slices"
+	"time"
+)
+
+// TabuOptions are the parameters of a tabu search run.
+type TabuOptions struct {
+	Tenure     int `json:"tenure"`
+	Iterations int `json:"iterations"`
+}
+
+// TabuSearch searches for a short tour by tabu search from a random tour. Each
+// iteration makes the best 2-opt move (reversing a segment) that is not tabu,
+// even if it lengthens the tour, to escape local optima. The two edges a move
+// removes are tabu for the next Tenure iterations: moves that add one back are
+// skipped, unless they would give the best tour found so far (the aspiration
+// criterion). The first city of an open tour can move too, by reversing a
+// prefix of the tour. The best tour found is returned.
+func TabuSearch(rng *rand.Rand, gt Genotype, opts TabuOptions) Result {
+	return TabuSearchContext(context.Background(), rng, gt, opts)
+}
+
+// TabuSearchContext is like TabuSearch, but also stops when the context is
+// done, returning the best tour found so far.
+func TabuSearchContext(ctx context.Context, rng *rand.Rand, gt Genotype, opts TabuOptions) Result {
+	start := time.Now()
+	done := ctx.Done()
+	tour := gt.RandomTour(rng)
+	score := tour.Score()
+	best, bestScore := slices.Clone(tour.path), score
+	n := len(tour.path)
+
+	// The last iteration in which each edge may not be added back
+	tabu := make([][]int, n)
+	for i := range tabu {
+		tabu[i] = make([]int, n)
+	}
+	isTabu := func(a, b, iteration int) bool {
+		return tabu[a][b] >= iteration || tabu[b][a] >= iteration
+	}
+
+	reason := StopMaxGenerations
+	iteration := 1
+	for ; iteration <= opts.Iterations; iteration++ {
+		if stopped(done) {
+			reason = canceled(ctx)
+			break
+		}
+		moveI, moveJ, moveDelta := -1, -1, math.Inf(1)
+		for i := tour.firstEdge(); i < n-2; i++ {
+			for j := i + 2; j < n; j++ {
+				if i <= 0 && j == n-1 {
+					continue
+				}
+				delta := tour.inversionDelta(i+1, j)
+				a, b, c, d := tour.path[(i+n)%n], tour.path[i+1], tour.path[j], tour.path[(j+1)%n]
+				if (isTabu(a, c, iteration) || isTabu(b, d, iteration)) && score+delta >= bestScore-minGain {
+					continue
+				}
+				if delta < moveDelta {
+					moveI, moveJ, moveDelta = i, j, delta
+				}
+			}
+		}
+		if moveJ < 0 {
+			reason = StopStagnation // Every move is tabu, or there are none
+			break
+		}
+		a, b := tour.path[(moveI+n)%n], tour.path[moveI+1]
+		c, d := tour.path[moveJ], tour.path[(moveJ+1)%n]
+		tabu[a][b], tabu[c][d] = iteration+opts.Tenure, iteration+opts.Tenure
+		slices.Reverse(tour.path[moveI+1 : moveJ+1])
+		if score += moveDelta; score < bestScore-minGain {
+			copy(best, tour.path)
+			bestScore = score
+		}
+	}
+	tour.path, tour.dirty = best, true
+	return Result{tour, tour.Score(), iteration - 1, time.Since(start), reason}
+}
@@ -0,0 +1,21 @@
+package tsp
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestTabuSearchOpen(t *testing.T) {
+	// The shortest open path through cities on a line runs from one end to the
+	// other, so tabu search has to move the first city of most random tours
+	gt := lineGenotype(t, 6)
+	for seed := range int64(20) {
+		result := TabuSearch(rand.New(rand.NewSource(seed)), gt, TabuOptions{Tenure: 3, Iterations: 100})
+		if math.Abs(result.Score-5) > 1e-9 {
+			t.Errorf("seed %d: score %f, want 5", seed, result.Score)
+		}
+		checkScore(t, result.Best, fmt.Sprintf("seed %d", seed))
+	}
+}
@@ -0,0 +1,13 @@
+package tsp
+
+// Gap returns how far a score is above a known optimum, as a percentage of the
+// optimum.
+func Gap(score, optimum float64) float64 {
+	return (score - optimum) / optimum * 100
+}
+
+// Determine whether a score is within the configured target gap of the known
+// optimum, so the search can stop early.
+func (c Config) targetReached(score float64) bool {
+	return c.Optimum > 0 && c.Target > 0 && Gap(score, c.Optimum) <= c.Target
+}
@@ -0,0 +1,62 @@
+package tsp
+
+import "time"
+
+// Terminator decides from the statistics of a GA island after each generation
+// whether the island should stop.
+type Terminator interface {
+	Stop(Stats) bool
+}
+
+// TerminatorFunc adapts a function to a Terminator.
+type TerminatorFunc func(Stats) bool
+
+// Stop calls the function.
+func (f TerminatorFunc) Stop(stats Stats) bool {
+	return f(stats)
+}
+
+// TimeLimit stops an island once the given time has elapsed.
+func TimeLimit(d time.Duration) Terminator {
+	return TerminatorFunc(func(stats Stats) bool { return stats.Elapsed >= d })
+}
+
+// MaxGenerations stops an island once it has evolved n generations.
+func MaxGenerations(n int) Terminator {
+	return TerminatorFunc(func(stats Stats) bool { return stats.Generation >= n })
+}
+
+// Stagnation stops an island once its best score has not improved for n
+// generations.
+func Stagnation(n int) Terminator {
+	return TerminatorFunc(func(stats Stats) bool { return stats.Stagnant >= n })
+}
+
+// TargetScore stops an island once its best tour scores at most the target.
+func TargetScore(target float64) Terminator {
+	return TerminatorFunc(func(stats Stats) bool { return stats.Score <= target })
+}
+
+// Any stops an island once any of the terminators would.
+func Any(terminators ...Terminator) Terminator {
+	return TerminatorFunc(func(stats Stats) bool {
+		for _, t := range terminators {
+			if t.Stop(stats) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// All stops an island once all of the terminators would.
+func All(terminators ...Terminator) Terminator {
+	return TerminatorFunc(func(stats Stats) bool {
+		for _, t := range terminators {
+			if !t.Stop(stats) {
+				return false
+			}
+		}
+		return true
+	})
+}
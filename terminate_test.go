@@ -0,0 +1,76 @@
+package tsp
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestTerminators(t *testing.T) {
+	stats := Stats{Generation: 10, Stagnant: 5, Elapsed: time.Second, Score: 100}
+	never, always := TerminatorFunc(func(Stats) bool { return false }), TerminatorFunc(func(Stats) bool { return true })
+	for _, test := range []struct {
+		name       string
+		terminator Terminator
+		want       bool
+	}{
+		{"time limit reached", TimeLimit(time.Second), true},
+		{"time limit not reached", TimeLimit(2 * time.Second), false},
+		{"max generations reached", MaxGenerations(10), true},
+		{"max generations not reached", MaxGenerations(11), false},
+		{"stagnation reached", Stagnation(5), true},
+		{"stagnation not reached", Stagnation(6), false},
+		{"target score reached", TargetScore(100), true},
+		{"target score not reached", TargetScore(99), false},
+		{"any of none", Any(), false},
+		{"any of one", Any(never, always), true},
+		{"any of neither", Any(never, never), false},
+		{"all of none", All(), true},
+		{"all of both", All(always, always), true},
+		{"all of one", All(always, never), false},
+		{"nested", Any(All(MaxGenerations(10), Stagnation(6)), TargetScore(50)), false},
+	} {
+		if got := test.terminator.Stop(stats); got != test.want {
+			t.Errorf("%s: stop %t, want %t", test.name, got, test.want)
+		}
+	}
+}
+
+func TestIslandStops(t *testing.T) {
+	gt := randomGenotype(rand.New(rand.NewSource(1)), 20, false, false)
+	for _, test := range []struct {
+		name   string
+		config func(*Config)
+		reason string
+		stop   func(Stats) bool
+	}{
+		{"max generations", func(cfg *Config) { cfg.MaxGenerations = 7 }, StopMaxGenerations, MaxGenerations(7).Stop},
+		{"stagnation", func(cfg *Config) { cfg.Stagnation = 3 }, StopStagnation, Stagnation(3).Stop},
+		{"terminator", func(cfg *Config) {
+			cfg.MaxGenerations, cfg.Terminator = 100, MaxGenerations(4)
+		}, StopTerminator, MaxGenerations(4).Stop},
+	} {
+		cfg := DefaultConfig()
+		cfg.Seed = 1
+		test.config(&cfg)
+		var last Stats
+		generations := 0
+		cfg.OnGeneration = func(stats Stats) {
+			if test.stop(last) && generations > 0 {
+				t.Errorf("%s: island went on after generation %d", test.name, last.Generation)
+			}
+			last = stats
+			generations++
+		}
+		result, err := SolveDeterministic(gt, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.StopReason != test.reason || !test.stop(last) {
+			t.Errorf("%s: stopped by %s after %+v", test.name, result.StopReason, last)
+		}
+		if result.Generations != last.Generation {
+			t.Errorf("%s: %d generations, last reported %d", test.name, result.Generations, last.Generation)
+		}
+	}
+}
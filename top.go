@@ -0,0 +1,33 @@
+package tsp
+
+import (
+	"cmp"
+	"slices"
+)
+
+// TopK returns copies of the k lowest scoring distinct tours of a population,
+// from best to worst. Tours with the same edges count as one (see Tour.Equal).
+// Fewer than k tours are returned if the population has fewer distinct tours,
+// and none if k is not positive.
+func (p Population) TopK(k int) []Tour {
+	return distinctBest(p.solutions, k)
+}
+
+// Return copies of the k lowest scoring distinct tours of a list.
+func distinctBest(tours []Tour, k int) []Tour {
+	k = max(k, 0)
+	sorted := slices.Clone(tours)
+	slices.SortStableFunc(sorted, func(a, b Tour) int {
+		return cmp.Compare(a.Score(), b.Score())
+	})
+	top := make([]Tour, 0, min(k, len(sorted)))
+	for _, tour := range sorted {
+		if len(top) == k {
+			break
+		}
+		if !slices.ContainsFunc(top, func(t Tour) bool { return t.Equal(tour, !t.open) }) {
+			top = append(top, tour.Clone())
+		}
+	}
+	return top
+}
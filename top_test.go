@@ -0,0 +1,39 @@
+package tsp
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestTopK(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	gt := randomGenotype(rng, 10, false, false)
+	p := Population{}
+	for range 5 {
+		tour := gt.RandomTour(rng)
+		rotated, reversed := tour.Clone(), tour.Clone()
+		rotated.rotate(3)
+		slices.Reverse(reversed.path)
+		p.solutions = append(p.solutions, tour, rotated, reversed)
+	}
+	for _, k := range []int{-1, 0, 1, 3, 5, 20} {
+		top := p.TopK(k)
+		if want := min(max(k, 0), 5); len(top) != want {
+			t.Errorf("top %d: %d tours, want %d", k, len(top), want)
+		}
+		for i := range top {
+			if i > 0 && top[i].Score() < top[i-1].Score() {
+				t.Errorf("top %d: tour %d scores %f, better than tour %d at %f", k, i, top[i].Score(), i-1, top[i-1].Score())
+			}
+			for j := range i {
+				if top[i].Equal(top[j], true) {
+					t.Errorf("top %d: tours %d and %d are equal", k, j, i)
+				}
+			}
+		}
+	}
+	if top := p.TopK(1); &top[0].path[0] == &p.Best().path[0] {
+		t.Error("top tour shares its path with the population")
+	}
+}
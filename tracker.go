@@ -0,0 +1,57 @@
+package tsp
+
+import (
+	"math"
+	"sync"
+)
+
+// BestTracker holds the best tour found by any GA island. It is safe for
+// concurrent use. Tours are copied in and out, so callers never share a path.
+type BestTracker struct {
+	mu    sync.Mutex
+	best  Tour
+	score float64
+	found bool
+}
+
+// Update records a tour if it is better than the best so far, and reports
+// whether it was.
+func (b *BestTracker) Update(tour Tour) bool {
+	score := tour.Score()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.found && score >= b.score {
+		return false
+	}
+	b.best, b.score, b.found = tour.Clone(), score, true
+	return true
+}
+
+// Best returns a copy of the best tour so far, and whether there is one.
+func (b *BestTracker) Best() (Tour, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.best.Clone(), b.found
+}
+
+// Score returns the score of the best tour so far, or math.MaxFloat64 if there
+// is none. It is cheaper than Best, since no tour is copied.
+func (b *BestTracker) Score() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.found {
+		return math.MaxFloat64
+	}
+	return b.score
+}
+
+// Replace the worst tour of a population with a copy of the global best, if
+// the global best is better than every tour of the population.
+func (p *Population) injectBest(b *BestTracker, score float64) {
+	if b.Score() >= score {
+		return
+	}
+	if best, ok := b.Best(); ok {
+		p.immigrate([]Tour{best})
+	}
+}
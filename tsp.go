@@ -0,0 +1,736 @@
+// Package tsp is a travelling salesman problem solver that uses a genetic
+// algorithm.
+package tsp
+
+import (
+	"bufio"
+	"cmp"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// There are pi radians per 180 degrees.
+const piRads = math.Pi / 180.0
+
+// The radius of Earth in miles.
+const radiusEarth = 3959.0
+
+// DistanceFunc measures the distance between two cities.
+type DistanceFunc func(c0, c1 City) float64
+
+// City is the name and location (latitude and longitude) of a city.
+type City struct {
+	Name  string
+	Lat   float64
+	Lon   float64
+	index int
+}
+
+// Tour is a path through all cities (a possible solution). The path is stored
+// as indices into the cities of the search space, which (like the distance
+// matrix) are shared by every tour and never changed. The score of a tour is
+// cached, so its path must only be changed through the mutating methods
+// (Shuffle and Mutate), which mark the cached score dirty. An open tour does not
+// return to its first city, so its score omits the wrap-around edge. The metric
+// of an asymmetric tour depends on the direction of each edge.
+type Tour struct {
+	path   []int
+	cities []City
+	matrix [][]float64
+	// Nearest neighbors of each city, for local search (nil for all cities)
+	neighbors [][]int
+	score     float64
+	dirty     bool
+	open      bool
+	asym      bool
+}
+
+// Genotype is the search space (the non optimized list of cities).
+type Genotype struct {
+	genes  []City
+	dist   DistanceFunc
+	matrix [][]float64
+	// Nearest neighbors of each city, for local search (nil for all cities)
+	neighbors [][]int
+	open      bool
+	asym      bool
+	planar    bool
+	strict    bool
+}
+
+// Population is a collection of tours to optimize.
+type Population struct {
+	solutions []Tour
+}
+
+// Return two distinct random values between zero and a given integer, in
+// order. If there are not two such values, both are zero.
+func randRange(rng *rand.Rand, n int) (int, int) {
+	if n <= 1 {
+		return 0, 0
+	}
+	r0, r1 := rng.Intn(n), rng.Intn(n)
+	for r0 == r1 {
+		r1 = rng.Intn(n)
+	}
+	if r1 < r0 {
+		return r1, r0
+	}
+	return r0, r1
+}
+
+// Great circle distance algorithm (haversine formula, which stays accurate
+// for nearby cities where the spherical law of cosines loses precision).
+func distance(c0, c1 City) float64 {
+	lat0, lon0 := c0.Lat, c0.Lon
+	lat1, lon1 := c1.Lat, c1.Lon
+	p0 := lat0 * piRads
+	p1 := lat1 * piRads
+	p2 := math.Sin((p1 - p0) / 2)
+	p3 := math.Sin((lon1*piRads - lon0*piRads) / 2)
+	p4 := p2*p2 + math.Cos(p0)*math.Cos(p1)*p3*p3
+	// Rounding can push antipodal points just past 1, where Asin is NaN
+	return 2 * radiusEarth * math.Asin(math.Sqrt(min(p4, 1)))
+}
+
+// Euclidean distance algorithm, for planar coordinates stored in Lat and Lon.
+func euclidean(c0, c1 City) float64 {
+	return math.Hypot(c1.Lat-c0.Lat, c1.Lon-c0.Lon)
+}
+
+// Create a city from an array of strings.
+func initCity(fields []string) (city City, err error) {
+	if len(fields) != 3 {
+		err = errors.New("Invalid line format")
+		return
+	}
+	name := strings.TrimSpace(fields[0])
+	var lat float64
+	lat, err = strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return
+	}
+	var lon float64
+	lon, err = strconv.ParseFloat(fields[2], 64)
+	if err != nil {
+		return
+	}
+	return City{Name: name, Lat: lat, Lon: lon}, nil
+}
+
+// Check that the coordinates of a city are a valid latitude and longitude.
+func checkCoords(city City) error {
+	if city.Lat < -90 || city.Lat > 90 {
+		return fmt.Errorf("Latitude out of range [-90, 90]: %g", city.Lat)
+	}
+	if city.Lon < -180 || city.Lon > 180 {
+		return fmt.Errorf("Longitude out of range [-180, 180]: %g", city.Lon)
+	}
+	return nil
+}
+
+// Copy clones a city
+func (c City) Copy() City {
+	return City{c.Name, c.Lat, c.Lon, c.index}
+}
+
+// Shuffle creates a randomized tour.
+func (t *Tour) Shuffle(rng *rand.Rand) {
+	rng.Shuffle(len(t.path), func(i, j int) {
+		t.path[i], t.path[j] = t.path[j], t.path[i]
+	})
+	t.dirty = true
+}
+
+// Return the k-th city visited by a tour.
+func (t Tour) city(k int) City {
+	return t.cities[t.path[k]]
+}
+
+// Print writes a string version of a tour to stdout.
+func (t Tour) Print() {
+	t.Fprint(os.Stdout)
+}
+
+// Fprint writes a string version of a tour to a writer: the names of the cities
+// in the order visited, separated by commas.
+func (t Tour) Fprint(w io.Writer) {
+	for k := range t.path {
+		fmt.Fprintf(w, "%s, ", t.city(k).Name)
+	}
+	fmt.Fprintf(w, "\n\n")
+}
+
+// WriteTo writes a tour to a writer in the input file format: one city per
+// line, in the order visited, then the total score as a trailing comment line.
+func (t Tour) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+	for k := range t.path {
+		city := t.city(k)
+		fmt.Fprintf(&b, "%s %g %g\n", city.Name, city.Lat, city.Lon)
+	}
+	fmt.Fprintf(&b, "# Score = %f\n", t.Score())
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// Clone returns a deep copy of a tour, which shares no path with the original.
+func (t Tour) Clone() Tour {
+	t.path = slices.Clone(t.path)
+	return t
+}
+
+// Cities returns the cities of a tour, in the order they are visited.
+func (t Tour) Cities() []City {
+	cities := make([]City, len(t.path))
+	for k := range t.path {
+		cities[k] = t.city(k)
+	}
+	return cities
+}
+
+// Path returns the indices (into the cities of the search space) of the cities
+// of a tour, in the order they are visited. The path is a copy.
+func (t Tour) Path() []int {
+	return slices.Clone(t.path)
+}
+
+// Contains determines whether a city lies within a given tour.
+func (t Tour) Contains(city City) bool {
+	return t.find(city.Name) >= 0
+}
+
+// Equal determines whether two tours visit the same cities (by name) in the
+// same order. If cyclic, tours are also equal when one is a rotation or a
+// reversal of the other, since they then have the same edges.
+func (t Tour) Equal(other Tour, cyclic bool) bool {
+	n := len(t.path)
+	if n != len(other.path) {
+		return false
+	}
+	if !cyclic || n == 0 {
+		for k := range t.path {
+			if t.city(k).Name != other.city(k).Name {
+				return false
+			}
+		}
+		return true
+	}
+	start := other.find(t.city(0).Name)
+	if start < 0 {
+		return false
+	}
+	forward, backward := true, true
+	for k := range t.path {
+		name := t.city(k).Name
+		forward = forward && other.city((start+k)%n).Name == name
+		backward = backward && other.city((start-k+n)%n).Name == name
+	}
+	return forward || backward
+}
+
+// Mutate is the mutation operator. With the given probability, it reverses a
+// random segment of the tour, and updates a cached score by rescoring only the
+// two edges that change.
+func (t *Tour) Mutate(rng *rand.Rand, rate float64) {
+	if rng.Float64() < rate {
+		mn, mx := randRange(rng, len(t.path))
+		t.score += t.inversionDelta(mn, mx)
+		for mn < mx {
+			t.path[mn], t.path[mx] = t.path[mx], t.path[mn]
+			mn, mx = mn+1, mx-1
+		}
+	}
+}
+
+// Return the change in score from reversing the segment between two indices.
+// For symmetric metrics, only the edges at either end of the segment change;
+// otherwise every edge within the segment changes direction too.
+func (t Tour) inversionDelta(mn, mx int) float64 {
+	n := len(t.path)
+	dist := t.metric()
+	delta := 0.0
+	if t.asym {
+		for k := mn; k < mx; k++ {
+			delta += dist(t.path[k+1], t.path[k]) - dist(t.path[k], t.path[k+1])
+		}
+	}
+	if mx-mn+1 >= n {
+		// Reversing the whole tour only turns the wrap-around edge around
+		if t.asym && !t.open && n > 1 {
+			delta += dist(t.path[mn], t.path[mx]) - dist(t.path[mx], t.path[mn])
+		}
+		return delta
+	}
+	prev, first := t.path[(mn-1+n)%n], t.path[mn]
+	last, next := t.path[mx], t.path[(mx+1)%n]
+	if !t.unscored(mn) {
+		delta += dist(prev, last) - dist(prev, first)
+	}
+	if !t.unscored(mx + 1) {
+		delta += dist(first, next) - dist(last, next)
+	}
+	return delta
+}
+
+// Report whether the edge into the given index (modulo the tour length) is the
+// wrap-around edge, which is not scored for an open tour.
+func (t Tour) unscored(next int) bool {
+	return t.open && next%len(t.path) == 0
+}
+
+// Rotate a tour so the city at index i is visited first.
+func (t *Tour) rotate(i int) {
+	slices.Reverse(t.path[:i])
+	slices.Reverse(t.path[i:])
+	slices.Reverse(t.path)
+}
+
+// create a new tour at random
+func makeChild(rng *rand.Rand, t1, t2 Tour) (child Tour) {
+	child = t1.emptyTour()
+	n := rng.Intn(len(t1.path))
+	child.path = make([]int, 0, len(t1.path))
+	child.path = append(child.path, t1.path[:n]...)
+	used := make([]bool, len(t1.cities))
+	for _, city := range child.path {
+		used[city] = true
+	}
+	for _, city := range t2.path {
+		if !used[city] {
+			child.path = append(child.path, city)
+			used[city] = true
+		}
+	}
+	return child
+}
+
+// Crossover is the reproduction operator. With the configured probability,
+// children are bred with the crossover operator named in the config, then
+// mutated (and optimized with 2-opt and Or-opt, if local search is enabled),
+// and finally repaired to keep any configured start and end cities in place.
+func (t Tour) Crossover(rng *rand.Rand, t2 Tour, cfg Config) (children []Tour) {
+	if rng.Float64() < cfg.CrossoverRate {
+		children = cfg.crossoverer().Crossover(rng, t, t2)
+		mutator := cfg.mutator()
+		for i := range children {
+			mutator.Mutate(rng, &children[i], cfg.MutationRate)
+			if cfg.LocalSearch {
+				children[i].localSearch()
+			}
+			children[i].anchor(cfg.Start, cfg.End)
+		}
+	}
+	return
+}
+
+// Breed children with the named crossover operator.
+func (t Tour) crossover(rng *rand.Rand, t2 Tour, op string) []Tour {
+	switch op {
+	case OrderCrossover:
+		return t.CrossoverOX(rng, t2)
+	case PMXCrossover:
+		return t.CrossoverPMX(rng, t2)
+	case CycleCrossover:
+		return t.CrossoverCX(t2)
+	case EdgeCrossover:
+		return t.CrossoverERX(rng, t2)
+	}
+	return []Tour{makeChild(rng, t, t2), makeChild(rng, t2, t)}
+}
+
+// Score is the total distance of a tour. It is computed lazily and cached until
+// the path changes.
+func (t *Tour) Score() float64 {
+	if t.dirty {
+		t.score, t.dirty = t.length(), false
+	}
+	return t.score
+}
+
+// Compute the total distance of a tour.
+func (t Tour) length() float64 {
+	return t.sum(t.metric())
+}
+
+// Sum the edges of a tour, measured by a function of the city indices. A tour
+// of fewer than two cities has no edges.
+func (t Tour) sum(dist func(a, b int) float64) float64 {
+	if len(t.path) < 2 {
+		return 0
+	}
+	n := len(t.path) - 1
+	score := 0.0
+	if !t.open {
+		score = dist(t.path[n], t.path[0])
+	}
+	for i := range n {
+		score += dist(t.path[i], t.path[i+1])
+	}
+	return score
+}
+
+// Return the function used to score an edge between the cities at two indices.
+// It looks up the distance matrix, or falls back to great circle distance.
+func (t Tour) metric() func(a, b int) float64 {
+	if t.matrix == nil {
+		cities := t.cities
+		return func(a, b int) float64 {
+			return distance(cities[a], cities[b])
+		}
+	}
+	matrix := t.matrix
+	return func(a, b int) float64 {
+		return matrix[a][b]
+	}
+}
+
+// Init initializes the search space from file (see InitReader). Files with a
+// .gz suffix are decompressed.
+func (gt *Genotype) Init(file string) error {
+	reader, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	if strings.HasSuffix(file, ".gz") {
+		unzipped, err := gzip.NewReader(reader)
+		if err != nil {
+			return err
+		}
+		defer unzipped.Close()
+		return gt.InitReader(unzipped)
+	}
+	return gt.InitReader(reader)
+}
+
+// InitReader initializes the search space from a reader. Input that starts with
+// a TSPLIB keyword is read as TSPLIB, otherwise each line is a 'name lat lon'
+// city (where the name may contain spaces). Blank lines and '#' comment lines
+// are skipped (unless the genotype is strict).
+func (gt *Genotype) InitReader(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(bufio.ScanLines)
+	if err := gt.initLines(scanner); err != nil {
+		return err
+	}
+	if err := gt.checkCities(); err != nil {
+		return err
+	}
+	gt.index()
+	return nil
+}
+
+// NewGenotype returns a search space of cities in memory, checked as when they
+// are read from a file (see InitCities).
+func NewGenotype(cities []City) (Genotype, error) {
+	gt := Genotype{}
+	err := gt.InitCities(cities)
+	return gt, err
+}
+
+// InitCities initializes the search space from a slice of cities, which must be
+// non-empty with distinct names (and valid coordinates unless planar).
+func (gt *Genotype) InitCities(cities []City) error {
+	for _, city := range cities {
+		if err := gt.checkCity(city); err != nil {
+			return err
+		}
+		gt.genes = append(gt.genes, city.Copy())
+	}
+	if err := gt.checkCities(); err != nil {
+		return err
+	}
+	gt.index()
+	return nil
+}
+
+// Read cities from each scanned line, in either TSPLIB or 'name lat lon' format.
+func (gt *Genotype) initLines(scanner *bufio.Scanner) error {
+	// Find the first line, which may be a TSPLIB keyword
+	var line string
+	found := false
+	for !found && scanner.Scan() {
+		line = strings.TrimSpace(scanner.Text())
+		found = gt.strict || !isComment(line)
+	}
+	if !found {
+		return scanner.Err()
+	}
+	if isTSPLIBKeyword(line) {
+		return gt.initTSPLIB(line, scanner)
+	}
+	for {
+		if !isComment(line) {
+			city, err := initCity(splitCity(line))
+			if err != nil {
+				return err
+			}
+			if err := gt.checkCity(city); err != nil {
+				return err
+			}
+			gt.genes = append(gt.genes, city)
+		} else if gt.strict {
+			return errors.New("Blank or comment line in strict input")
+		}
+		if !scanner.Scan() {
+			return scanner.Err()
+		}
+		line = scanner.Text()
+	}
+}
+
+// Split a 'name lat lon' line into fields. The name is everything before the
+// last two fields, so it may contain spaces.
+func splitCity(line string) []string {
+	fields := strings.Fields(line)
+	if n := len(fields); n > 3 {
+		fields = []string{strings.Join(fields[:n-2], " "), fields[n-2], fields[n-1]}
+	}
+	return fields
+}
+
+// Determine whether a line is blank or a comment (starting with '#').
+func isComment(line string) bool {
+	line = strings.TrimSpace(line)
+	return line == "" || strings.HasPrefix(line, "#")
+}
+
+// Check the coordinates of a city read into the search space, unless they are
+// planar.
+func (gt Genotype) checkCity(city City) error {
+	if gt.planar {
+		return nil
+	}
+	return checkCoords(city)
+}
+
+// Check that the search space has cities, and that no city name appears more
+// than once (since tours tell cities apart by name).
+func (gt Genotype) checkCities() error {
+	if len(gt.genes) == 0 {
+		return errors.New("No cities found")
+	}
+	seen := make(map[string]bool, len(gt.genes))
+	var duplicates []string
+	for _, city := range gt.genes {
+		if seen[city.Name] && !slices.Contains(duplicates, city.Name) {
+			duplicates = append(duplicates, city.Name)
+		}
+		seen[city.Name] = true
+	}
+	if len(duplicates) > 0 {
+		return fmt.Errorf("Duplicate cities: %s", strings.Join(duplicates, ", "))
+	}
+	return nil
+}
+
+// Cities returns the cities of the search space.
+func (gt Genotype) Cities() []City {
+	return slices.Clone(gt.genes)
+}
+
+// SetOpen sets whether tours of the search space are open paths that do not
+// return to their first city.
+func (gt *Genotype) SetOpen(open bool) {
+	gt.open = open
+}
+
+// SetPlanar sets whether the cities of the search space have planar (x, y)
+// coordinates stored in Lat and Lon, rather than a latitude and longitude.
+// Planar coordinates are not range checked and are scored by euclidean
+// distance (unless another metric is set). It must be called before loading
+// cities.
+func (gt *Genotype) SetPlanar(planar bool) {
+	gt.planar = planar
+}
+
+// SetStrict sets whether blank lines and '#' comment lines in a file of cities
+// are errors rather than skipped. It must be called before loading cities.
+func (gt *Genotype) SetStrict(strict bool) {
+	gt.strict = strict
+}
+
+// SetDistance changes the metric used to score tours (great circle miles by
+// default) and recomputes the distance matrix.
+func (gt *Genotype) SetDistance(dist DistanceFunc) {
+	gt.dist = dist
+	gt.index()
+}
+
+// Index the cities of the search space and precompute the distance between
+// every pair, so scoring an edge is a matrix lookup instead of trigonometry.
+func (gt *Genotype) index() {
+	dist := gt.dist
+	if dist == nil && gt.planar {
+		dist = euclidean
+	} else if dist == nil {
+		dist = distance
+	}
+	for i := range gt.genes {
+		gt.genes[i].index = i
+	}
+	gt.matrix = make([][]float64, len(gt.genes))
+	for i, c0 := range gt.genes {
+		gt.matrix[i] = make([]float64, len(gt.genes))
+		for j, c1 := range gt.genes {
+			gt.matrix[i][j] = dist(c0, c1)
+		}
+	}
+	gt.asym = false
+	for i := range gt.matrix {
+		for j := range i {
+			gt.asym = gt.asym || gt.matrix[i][j] != gt.matrix[j][i]
+		}
+	}
+}
+
+// SetMatrix scores tours with an explicit matrix of distances between the cities
+// of the search space, where matrix[i][j] is the distance from the i-th city to
+// the j-th. The matrix may be asymmetric.
+func (gt *Genotype) SetMatrix(matrix [][]float64) error {
+	n := len(gt.genes)
+	if len(matrix) != n {
+		return fmt.Errorf("Matrix has %d rows, expected %d", len(matrix), n)
+	}
+	for i, row := range matrix {
+		if len(row) != n {
+			return fmt.Errorf("Matrix row %d has %d columns, expected %d", i+1, len(row), n)
+		}
+	}
+	for i := range gt.genes {
+		gt.genes[i].index = i
+	}
+	gt.dist = func(c0, c1 City) float64 {
+		return matrix[c0.index][c1.index]
+	}
+	gt.index()
+	return nil
+}
+
+// Return a tour with no path, scored like the tours of the search space.
+func (gt Genotype) emptyTour() Tour {
+	return Tour{cities: gt.genes, matrix: gt.matrix, neighbors: gt.neighbors, dirty: true, open: gt.open, asym: gt.asym}
+}
+
+// Return a tour with no path, scored like a given tour.
+func (t Tour) emptyTour() Tour {
+	return Tour{cities: t.cities, matrix: t.matrix, neighbors: t.neighbors, dirty: true, open: t.open, asym: t.asym}
+}
+
+// TourOf creates a tour of the search space that visits the cities at the given
+// indices in order (like the path of another tour), to be scored afresh. It
+// returns an error unless the path visits every city exactly once.
+func (gt Genotype) TourOf(path []int) (Tour, error) {
+	tour := gt.emptyTour()
+	tour.path = slices.Clone(path)
+	if err := tour.Validate(gt); err != nil {
+		return Tour{}, err
+	}
+	return tour, nil
+}
+
+// RandomTour creates a random tour from the search space.
+func (gt Genotype) RandomTour(rng *rand.Rand) (tour Tour) {
+	tour = gt.emptyTour()
+	tour.path = make([]int, len(gt.genes))
+	for i := range tour.path {
+		tour.path[i] = i
+	}
+	tour.Shuffle(rng)
+	return
+}
+
+// Init initializes a population of tours. A fraction of the tours are built by
+// heuristics: one greedy edge tour, then nearest neighbor tours (from different
+// random start cities, so at most one per city). The rest are random.
+func (p *Population) Init(rng *rand.Rand, gt Genotype, size int, heuristic float64) {
+	p.solutions = make([]Tour, size)
+	starts := rng.Perm(len(gt.genes))
+	seeded := min(int(heuristic*float64(size)), len(starts)+1)
+	for i := range size {
+		if i == 0 && seeded > 0 {
+			p.solutions[i] = gt.GreedyTour()
+		} else if i < seeded {
+			p.solutions[i] = gt.NearestNeighborTour(starts[i-1])
+		} else {
+			p.solutions[i] = gt.RandomTour(rng)
+		}
+	}
+}
+
+// Best returns the tour with the shortest path (lowest score).
+func (p Population) Best() (best Tour) {
+	bestScore := math.MaxFloat64
+	for i := range p.solutions {
+		currentScore := p.solutions[i].Score()
+		if currentScore < bestScore {
+			best = p.solutions[i]
+			bestScore = currentScore
+		}
+	}
+	return
+}
+
+// Select is the uniform selection operator: it returns two distinct random
+// tours. A population of one tour is paired with itself.
+func (p Population) Select(rng *rand.Rand) (Tour, Tour) {
+	r1, r2 := randRange(rng, len(p.solutions))
+	return p.solutions[r1], p.solutions[r2]
+}
+
+// Evolve moves the population forward a single generation. Each child replaces
+// a random tour (or with steady-state replacement, the worst tour, or with
+// crowding, the tour sharing the most edges with it) if it is no worse. The
+// best tours (the elite) are carried into the next generation untouched. If the
+// config checks children, Evolve panics on any child that is not a permutation
+// of the cities of its parents, since that is a bug in an operator.
+func (p *Population) Evolve(rng *rand.Rand, cfg Config) {
+	elite := p.elite(cfg.Elitism)
+	selector := cfg.selector()
+	for range cfg.Offspring / 2 {
+		p0, p1 := selector.Select(rng, *p)
+		for _, child := range p0.Crossover(rng, p1, cfg) {
+			if cfg.CheckChildren {
+				if err := child.validate(p0.Cities()); err != nil {
+					panic(fmt.Sprintf("Invalid child from %s crossover: %v", cfg.Crossover, err))
+				}
+			}
+			if i := p.replaced(rng, cfg, child, elite); i >= 0 {
+				p.solutions[i] = child
+			}
+		}
+	}
+}
+
+// Return the indices of the e tours with the lowest scores.
+func (p Population) elite(e int) map[int]bool {
+	if e == 0 {
+		return nil
+	}
+	elite := make(map[int]bool, e)
+	for _, i := range p.ranked()[:e] {
+		elite[i] = true
+	}
+	return elite
+}
+
+// Return the indices of the tours, from lowest to highest score.
+func (p Population) ranked() []int {
+	order := make([]int, len(p.solutions))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortFunc(order, func(i, j int) int {
+		return cmp.Compare(p.solutions[i].Score(), p.solutions[j].Score())
+	})
+	return order
+}
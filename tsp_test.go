@@ -0,0 +1,223 @@
+package tsp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestInitReaderComments(t *testing.T) {
+	input := "\n# Cities\n  \nA 1 2\n# B 3 4\n\nC 5 6\n"
+	gt := Genotype{}
+	if err := gt.InitReader(strings.NewReader(input)); err != nil {
+		t.Fatal(err)
+	}
+	if cities := gt.Cities(); len(cities) != 2 || cities[0].Name != "A" || cities[1].Name != "C" {
+		t.Errorf("read cities %v, want A and C", cities)
+	}
+	for _, input := range []string{input, "\nA 1 2\nC 5 6\n", "# Cities\nA 1 2\n", "A 1 2\n\nC 5 6\n", "A 1 2\n# B 3 4\n"} {
+		gt := Genotype{}
+		gt.SetStrict(true)
+		if err := gt.InitReader(strings.NewReader(input)); err == nil {
+			t.Errorf("strict genotype read %q", input)
+		}
+	}
+	gt = Genotype{}
+	gt.SetStrict(true)
+	if err := gt.InitReader(strings.NewReader("A 1 2\nC 5 6\n")); err != nil || len(gt.genes) != 2 {
+		t.Errorf("strict genotype read %d cities (error %v), want 2", len(gt.genes), err)
+	}
+}
+
+func TestDistance(t *testing.T) {
+	halfway := math.Pi * radiusEarth
+	for _, test := range []struct {
+		c0, c1 City
+		want   float64
+	}{
+		{City{Lat: 40, Lon: -75}, City{Lat: 40, Lon: -75}, 0},
+		// A millionth of a degree of latitude is a millionth of a degree of arc
+		{City{Lat: 40, Lon: -75}, City{Lat: 40.000001, Lon: -75}, halfway / 180e6},
+		{City{Lat: 0, Lon: 0}, City{Lat: 0, Lon: 180}, halfway},
+		{City{Lat: 45, Lon: 10}, City{Lat: -45, Lon: -170}, halfway},
+		{City{Lat: 90, Lon: 0}, City{Lat: -90, Lon: 0}, halfway},
+		{City{Lat: 37.7749, Lon: -122.4194}, City{Lat: -37.7749, Lon: 57.5806}, halfway},
+	} {
+		for _, got := range []float64{distance(test.c0, test.c1), distance(test.c1, test.c0)} {
+			if math.IsNaN(got) || math.IsInf(got, 0) || math.Abs(got-test.want) > 1e-6*max(1, test.want) {
+				t.Errorf("distance from %v to %v is %g, want %g", test.c0, test.c1, got, test.want)
+			}
+		}
+	}
+}
+
+func TestEvolveKeepsBest(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	gt := randomGenotype(rng, 30, false, false)
+	for _, replacement := range []string{GenerationalReplacement, SteadyStateReplacement, CrowdingReplacement} {
+		for _, elitism := range []int{0, 2} {
+			cfg := DefaultConfig()
+			cfg.Replacement, cfg.Elitism, cfg.CheckChildren = replacement, elitism, true
+			p := Population{}
+			p.Init(rng, gt, cfg.Population, cfg.HeuristicFraction)
+			best := p.Best()
+			for generation := range 200 {
+				p.Evolve(rng, cfg)
+				next := p.Best()
+				if next.Score() > best.Score() {
+					t.Fatalf("%s replacement, elitism %d: best score rose from %f to %f in generation %d", replacement, elitism, best.Score(), next.Score(), generation)
+				}
+				best = next
+			}
+		}
+	}
+}
+
+// Return the names of the cities of a genotype, in order.
+func cityNames(gt Genotype) (names []string) {
+	for _, city := range gt.genes {
+		names = append(names, city.Name)
+	}
+	return
+}
+
+func TestInitReader(t *testing.T) {
+	for _, test := range []struct {
+		input  string
+		planar bool
+		names  string // The names of the cities read, comma separated
+		err    string // The error wanted, if any
+	}{
+		{input: "A 1 2\nB 3 4", names: "A,B"},
+		{input: "New York 40.7 -74.0\nLos  Angeles 34.05 -118.24\n", names: "New York,Los Angeles"},
+		{input: "A 90 180\nB -90 -180\n", names: "A,B"},
+		{input: "A 91 0\nB 0 0\n", planar: true, names: "A,B"},
+		{input: "A 1 2\nB 3 4\nA 5 6\nB 7 8\n", err: "Duplicate cities: A, B"},
+		{input: "", err: "No cities found"},
+		{input: "# Nothing\n\n", err: "No cities found"},
+		{input: "A 91 0\n", err: "Latitude out of range [-90, 90]: 91"},
+		{input: "A -90.5 0\n", err: "Latitude out of range [-90, 90]: -90.5"},
+		{input: "A 0 181\n", err: "Longitude out of range [-180, 180]: 181"},
+		{input: "A 1\n", err: "Invalid line format"},
+		{input: "A x 2\n", err: "strconv.ParseFloat: parsing \"x\": invalid syntax"},
+	} {
+		gt := Genotype{}
+		gt.SetPlanar(test.planar)
+		err := gt.InitReader(strings.NewReader(test.input))
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("%q: error %v, want %s", test.input, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %s", test.input, err)
+		} else if got := strings.Join(cityNames(gt), ","); got != test.names {
+			t.Errorf("%q: read %s, want %s", test.input, got, test.names)
+		}
+	}
+}
+
+func TestInit(t *testing.T) {
+	dir := t.TempDir()
+	plain, zipped, corrupt := filepath.Join(dir, "capitals.tsp"), filepath.Join(dir, "capitals.tsp.gz"), filepath.Join(dir, "corrupt.tsp.gz")
+	if err := os.WriteFile(plain, []byte(capitals), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(corrupt, []byte(capitals), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	w.Write([]byte(capitals))
+	w.Close()
+	if err := os.WriteFile(zipped, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	want := Genotype{}
+	if err := want.InitReader(strings.NewReader(capitals)); err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range []string{plain, zipped} {
+		gt := Genotype{}
+		if err := gt.Init(file); err != nil {
+			t.Fatalf("%s: %s", file, err)
+		}
+		if !slices.Equal(gt.genes, want.genes) {
+			t.Errorf("%s: read %d cities, want the %d capitals", file, len(gt.genes), len(want.genes))
+		}
+	}
+	for _, file := range []string{corrupt, filepath.Join(dir, "missing.tsp")} {
+		gt := Genotype{}
+		if err := gt.Init(file); err == nil {
+			t.Errorf("%s: no error", file)
+		}
+	}
+}
+
+func TestTourEqual(t *testing.T) {
+	gt := randomGenotype(rand.New(rand.NewSource(1)), 6, false, false)
+	tour := gt.emptyTour()
+	tour.path = []int{0, 1, 2, 3, 4, 5}
+	for _, test := range []struct {
+		path          []int
+		equal, cyclic bool
+	}{
+		{[]int{0, 1, 2, 3, 4, 5}, true, true},
+		{[]int{2, 3, 4, 5, 0, 1}, false, true},
+		{[]int{5, 4, 3, 2, 1, 0}, false, true},
+		{[]int{3, 2, 1, 0, 5, 4}, false, true},
+		{[]int{0, 1, 2, 3, 5, 4}, false, false},
+		{[]int{0, 1, 2, 3, 4}, false, false},
+	} {
+		other := gt.emptyTour()
+		other.path = test.path
+		if got := tour.Equal(other, false); got != test.equal {
+			t.Errorf("%v: equal %t", test.path, got)
+		}
+		if got := tour.Equal(other, true); got != test.cyclic {
+			t.Errorf("%v: cyclically equal %t", test.path, got)
+		}
+	}
+}
+
+func TestTourClone(t *testing.T) {
+	gt := randomGenotype(rand.New(rand.NewSource(1)), 6, false, false)
+	tour := gt.RandomTour(rand.New(rand.NewSource(1)))
+	score := tour.Score()
+	clone := tour.Clone()
+	clone.Mutate(rand.New(rand.NewSource(1)), 1)
+	if slices.Equal(clone.path, tour.path) || tour.Score() != score || tour.length() != score {
+		t.Error("mutating a clone changed the original tour")
+	}
+}
+
+func TestTourValidate(t *testing.T) {
+	gt := randomGenotype(rand.New(rand.NewSource(1)), 4, false, false)
+	for _, test := range []struct {
+		path []int
+		err  string
+	}{
+		{[]int{0, 1, 2}, "Tour visits 3 cities, expected 4"},
+		{[]int{0, 1, 2, 4}, "Invalid city index in tour: 4"},
+		{[]int{0, 1, 2, 1}, "City visited twice: 1"},
+	} {
+		tour := gt.emptyTour()
+		tour.path = test.path
+		if err := tour.Validate(gt); err == nil || err.Error() != test.err {
+			t.Errorf("%v: error %v, want %s", test.path, err, test.err)
+		}
+	}
+	other := randomGenotype(rand.New(rand.NewSource(2)), 4, false, false)
+	other.genes[3].Name = "x"
+	if err := gt.RandomTour(rand.New(rand.NewSource(1))).Validate(other); err == nil {
+		t.Error("tour of other cities accepted")
+	}
+}
@@ -0,0 +1,81 @@
+package tsp
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Keywords that may appear in the specification part of a TSPLIB file.
+var tsplibKeywords = map[string]bool{
+	"NAME":               true,
+	"TYPE":               true,
+	"COMMENT":            true,
+	"DIMENSION":          true,
+	"EDGE_WEIGHT_TYPE":   true,
+	"NODE_COORD_SECTION": true,
+	"EOF":                true,
+}
+
+// Determine whether a line starts with a TSPLIB keyword.
+func isTSPLIBKeyword(line string) bool {
+	key, _, _ := strings.Cut(line, ":")
+	return tsplibKeywords[strings.TrimSpace(key)]
+}
+
+// Read a TSPLIB file, starting at the given (already scanned) header line.
+func (gt *Genotype) initTSPLIB(line string, scanner *bufio.Scanner) error {
+	for {
+		key, value, _ := strings.Cut(line, ":")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch key {
+		case "TYPE":
+			if value != "TSP" {
+				return fmt.Errorf("Unsupported TSPLIB type: %s", value)
+			}
+		case "EDGE_WEIGHT_TYPE":
+			if value != "EUC_2D" {
+				return fmt.Errorf("Unsupported TSPLIB edge weight type: %s", value)
+			}
+		case "NODE_COORD_SECTION":
+			gt.dist = euc2D
+			return gt.initNodeCoords(scanner)
+		case "EOF":
+			return nil
+		}
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return err
+			}
+			return errors.New("Missing NODE_COORD_SECTION")
+		}
+		line = strings.TrimSpace(scanner.Text())
+	}
+}
+
+// Read 'index x y' lines of a TSPLIB node coordinate section until EOF.
+// The node index is used as the city name.
+func (gt *Genotype) initNodeCoords(scanner *bufio.Scanner) error {
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] == "EOF" {
+			break
+		}
+		city, err := initCity(fields)
+		if err != nil {
+			return err
+		}
+		gt.genes = append(gt.genes, city)
+	}
+	return scanner.Err()
+}
+
+// TSPLIB EUC_2D distance: euclidean distance rounded to the nearest integer.
+func euc2D(c0, c1 City) float64 {
+	return math.Round(euclidean(c0, c1))
+}
@@ -0,0 +1,37 @@
+package tsp
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInitTSPLIB(t *testing.T) {
+	input := "NAME: rectangle\nTYPE: TSP\nCOMMENT: A rectangle\nDIMENSION: 4\nEDGE_WEIGHT_TYPE: EUC_2D\nNODE_COORD_SECTION\n1 0 0\n2 0 10.4\n3 10.6 10.4\n4 10.6 0\nEOF\n"
+	gt := Genotype{}
+	if err := gt.InitReader(strings.NewReader(input)); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(cityNames(gt), ","); got != "1,2,3,4" {
+		t.Fatalf("read %s, want 1,2,3,4", got)
+	}
+	// EUC_2D distances are rounded to the nearest integer
+	tour := gt.emptyTour()
+	tour.path = []int{0, 1, 2, 3}
+	if got := tour.Score(); got != 10+11+10+11 {
+		t.Errorf("tour scores %f, want 42", got)
+	}
+	for _, test := range []struct {
+		input, err string
+	}{
+		{"NAME: x\nTYPE: ATSP\n", "Unsupported TSPLIB type: ATSP"},
+		{"NAME: x\nEDGE_WEIGHT_TYPE: GEO\n", "Unsupported TSPLIB edge weight type: GEO"},
+		{"NAME: x\nTYPE: TSP\n", "Missing NODE_COORD_SECTION"},
+		{"NAME: x\nNODE_COORD_SECTION\n1 0\n", "Invalid line format"},
+		{"NAME: x\nNODE_COORD_SECTION\nEOF\n", "No cities found"},
+	} {
+		gt := Genotype{}
+		if err := gt.InitReader(strings.NewReader(test.input)); err == nil || err.Error() != test.err {
+			t.Errorf("%q: error %v, want %s", test.input, err, test.err)
+		}
+	}
+}
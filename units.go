@@ -0,0 +1,63 @@
+package tsp
+
+import "fmt"
+
+// Unit is a unit of distance, expressed as the radius of Earth in that unit.
+type Unit float64
+
+// Supported units of distance.
+const (
+	Miles         Unit = radiusEarth
+	Kilometers    Unit = 6371.0
+	NauticalMiles Unit = 3440.065
+)
+
+// String returns the abbreviated name of a unit.
+func (u Unit) String() string {
+	switch u {
+	case Miles:
+		return "mi"
+	case Kilometers:
+		return "km"
+	case NauticalMiles:
+		return "nmi"
+	}
+	return fmt.Sprintf("Unit(%g)", float64(u))
+}
+
+// Set parses a unit from its abbreviated name (so a unit can be a flag.Value).
+func (u *Unit) Set(s string) error {
+	switch s {
+	case "mi", "miles":
+		*u = Miles
+	case "km", "kilometers":
+		*u = Kilometers
+	case "nmi", "nautical-miles":
+		*u = NauticalMiles
+	default:
+		return fmt.Errorf("Unknown unit: %s", s)
+	}
+	return nil
+}
+
+// Distance is the total great circle distance of a tour in the given unit. It
+// always measures the tour geographically, whatever metric scores it.
+func (t Tour) Distance(unit Unit) float64 {
+	return t.sum(func(a, b int) float64 {
+		return distance(t.cities[a], t.cities[b]) * float64(unit) / radiusEarth
+	})
+}
+
+// ScoreIn is the total great circle distance of a tour in the given unit, for a
+// tour of a geographic search space (see Genotype.Geographic). Units only scale
+// the score, so they never change which tour is best.
+func (t Tour) ScoreIn(unit Unit) float64 {
+	return t.Score() * float64(unit) / radiusEarth
+}
+
+// Geographic reports whether tours of the search space are scored by great
+// circle distance in miles, so their scores can be converted to other units.
+// Planar, TSPLIB and matrix distances have no unit.
+func (gt Genotype) Geographic() bool {
+	return gt.dist == nil && !gt.planar
+}
@@ -0,0 +1,27 @@
+package tsp
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestGeographic(t *testing.T) {
+	gt := randomGenotype(rand.New(rand.NewSource(1)), 5, false, false)
+	if !gt.Geographic() {
+		t.Error("cities with great circle distances are not geographic")
+	}
+	tour := gt.RandomTour(rand.New(rand.NewSource(1)))
+	if got, want := tour.ScoreIn(Kilometers), tour.Score()*6371/3959; math.Abs(got-want) > 1e-9 {
+		t.Errorf("score %f km, want %f", got, want)
+	}
+	planar := gt
+	planar.SetPlanar(true)
+	if planar.Geographic() {
+		t.Error("planar cities are geographic")
+	}
+	gt.SetDistance(euc2D)
+	if gt.Geographic() {
+		t.Error("cities with TSPLIB distances are geographic")
+	}
+}
@@ -0,0 +1,41 @@
+package tsp
+
+import "fmt"
+
+// Validate checks that a tour is a permutation of the cities of a genotype:
+// every city is visited exactly once. It returns an error describing the first
+// problem found.
+func (t Tour) Validate(gt Genotype) error {
+	return t.validate(gt.genes)
+}
+
+// Check that a tour visits every one of the given cities exactly once.
+func (t Tour) validate(cities []City) error {
+	if len(t.path) != len(cities) {
+		return fmt.Errorf("Tour visits %d cities, expected %d", len(t.path), len(cities))
+	}
+	visits := make(map[string]int, len(cities))
+	for _, city := range cities {
+		visits[city.Name] = 0
+	}
+	for _, i := range t.path {
+		if i < 0 || i >= len(t.cities) {
+			return fmt.Errorf("Invalid city index in tour: %d", i)
+		}
+		city := t.cities[i]
+		n, ok := visits[city.Name]
+		if !ok {
+			return fmt.Errorf("Unknown city in tour: %s", city.Name)
+		}
+		if n > 0 {
+			return fmt.Errorf("City visited twice: %s", city.Name)
+		}
+		visits[city.Name] = n + 1
+	}
+	for _, city := range cities {
+		if visits[city.Name] == 0 {
+			return fmt.Errorf("City not visited: %s", city.Name)
+		}
+	}
+	return nil
+}